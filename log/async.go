@@ -0,0 +1,494 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+// Overflow policy of an Async Logger, that is, what to do with an entry when
+// its queue is full.
+type Overflow int
+
+const (
+	// Block waits for there to be room in the queue.
+	Block Overflow = iota
+
+	// DropNewest drops the entry being made.
+	DropNewest
+
+	// DropOldest drops the oldest entry in the queue to make room.
+	DropOldest
+)
+
+var (
+	// ErrDropped is returned by Async.Entry when the entry is dropped.
+	ErrDropped = errors.New("log: entry dropped")
+
+	// ErrClosed is returned by Async.Entry when the Async has been closed.
+	ErrClosed = errors.New("log: logger closed")
+)
+
+// Async is a Logger that makes entries to another Logger in the background.
+//
+// Entries are queued and made in order by a goroutine, so that a slow Logger
+// doesn’t stall the caller.  Loggers derived from an Async by Named or With are
+// Asyncs that share its queue.
+//
+// As the values of an entry are written before it’s queued, values of Lazy
+// lose their laziness, as described by Entry.
+type Async struct {
+	logger Logger
+	q      *asyncQueue
+}
+
+// NewAsync is a new Async that makes entries to l through a queue of at most
+// size entries, using overflow when the queue is full.  A size less than one
+// is taken as one.
+//
+// The Async must be closed with Close to stop its goroutine.
+func NewAsync(l Logger, size int, overflow Overflow) *Async {
+	if size < 1 {
+		size = 1
+	}
+	q := &asyncQueue{
+		size:     size,
+		overflow: overflow,
+		drained:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	close(q.drained)
+	q.cond.L = &q.mu
+	go q.run()
+	return &Async{l, q}
+}
+
+// Entry queues message and a snapshot of fields for the background goroutine.
+//
+// Anything that depends on where the entry is made, such as the caller added
+// by a Logger of WithCaller, is resolved before the entry is queued.
+//
+// The snapshot is taken by writing the values of fields, so that whatever they
+// refer to, such as the value of a value.Struct, may be modified after the
+// call.  The values of a value.Reflect are deep copies of their values, a
+// value.Func is called, and a value.Stringer is written as a value.String.  A
+// value.Error keeps its type, but its error is replaced by one that writes the
+// snapshot of the value.Error.
+//
+// As a value.Func, such as that of Lazy, is thus called before the entry is
+// queued, it’s called even if the wrapped Logger turns out not to make the
+// entry, unless the wrapped Logger is one of Ruled that’s disabled, in which
+// case the entry isn’t queued at all.
+//
+// Errors with ErrDropped if the queue is full and the Overflow is DropNewest,
+// with ErrClosed if a has been closed, and if writing the values of fields
+// does.
+func (a *Async) Entry(message string, fields ...Field) error {
+	l := a.logger
+	for b, ok := l.(binder); ok; b, ok = l.(binder) {
+		l, fields = b.bind(fields)
+	}
+	if e, ok := l.(enabler); ok && !e.enabled() {
+		return nil
+	}
+	s, err := snapshot(fields)
+	if err != nil {
+		return err
+	}
+	return a.q.push(asyncEntry{l, message, s})
+}
+
+// binder is implemented by Loggers whose entries depend on where they’re made,
+// so that an Async can resolve that before it queues an entry.
+type binder interface {
+	// bind an entry with fields to where it’s being made, resulting in the
+	// Logger to make it to and the fields to make it with.
+	bind(fields []Field) (Logger, []Field)
+}
+
+// enabler is implemented by Loggers that know whether they make entries before
+// they’re made, so that an Async needn’t take a snapshot of those it doesn’t.
+type enabler interface {
+	// enabled is true if entries are made.
+	enabled() bool
+}
+
+// Named is a new Async wrapping a’s Logger’s Named(name) that shares a’s queue.
+func (a *Async) Named(name string) Logger {
+	if name == "" {
+		return a
+	}
+	return &Async{a.logger.Named(name), a.q}
+}
+
+// With is a new Async wrapping a’s Logger’s With(fields...) that shares a’s
+// queue.
+func (a *Async) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return a
+	}
+	return &Async{a.logger.With(fields...), a.q}
+}
+
+// Dropped is the number of entries that have been dropped due to overflow.
+func (a *Async) Dropped() uint64 {
+	a.q.mu.Lock()
+	defer a.q.mu.Unlock()
+	return a.q.dropped
+}
+
+// Flush waits until the queue is empty and no entry is being made.
+//
+// Errors if ctx is done first or with the first error returned by the
+// wrapped Logger since the last call to Flush or Close.
+func (a *Async) Flush(ctx context.Context) error {
+	a.q.mu.Lock()
+	drained := a.q.drained
+	a.q.mu.Unlock()
+	select {
+	case <-drained:
+		return a.q.takeErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting entries and waits until the queued entries have been
+// made and the background goroutine has stopped.
+//
+// Errors like Flush.
+func (a *Async) Close(ctx context.Context) error {
+	a.q.mu.Lock()
+	if !a.q.closed {
+		a.q.closed = true
+		a.q.cond.Broadcast()
+	}
+	a.q.mu.Unlock()
+	select {
+	case <-a.q.done:
+		return a.q.takeErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type asyncEntry struct {
+	logger  Logger
+	message string
+	fields  []Field
+}
+
+type asyncQueue struct {
+	mu       sync.Mutex
+	cond     sync.Cond
+	size     int
+	overflow Overflow
+	entries  []asyncEntry
+	pending  int // pending is len(entries) plus any entry being made.
+	drained  chan struct{}
+	dropped  uint64
+	closed   bool
+	err      error
+	done     chan struct{}
+}
+
+func (q *asyncQueue) push(e asyncEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for !q.closed && len(q.entries) >= q.size {
+		switch q.overflow {
+		case DropNewest:
+			q.dropped++
+			return ErrDropped
+		case DropOldest:
+			q.entries[0] = asyncEntry{}
+			q.entries = q.entries[1:]
+			q.pending--
+			if q.pending == 0 {
+				close(q.drained)
+			}
+			q.dropped++
+		default:
+			q.cond.Wait()
+		}
+	}
+	if q.closed {
+		return ErrClosed
+	}
+	q.entries = append(q.entries, e)
+	if q.pending == 0 {
+		q.drained = make(chan struct{})
+	}
+	q.pending++
+	q.cond.Broadcast()
+	return nil
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		for len(q.entries) == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if len(q.entries) == 0 {
+			return
+		}
+		e := q.entries[0]
+		q.entries[0] = asyncEntry{}
+		q.entries = q.entries[1:]
+		q.cond.Broadcast()
+		q.mu.Unlock()
+		err := e.logger.Entry(e.message, e.fields...)
+		q.mu.Lock()
+		if err != nil && q.err == nil {
+			q.err = err
+		}
+		q.pending--
+		if q.pending == 0 {
+			close(q.drained)
+		}
+	}
+}
+
+func (q *asyncQueue) takeErr() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	err := q.err
+	q.err = nil
+	return err
+}
+
+// snapshot of fields, as described by Async.Entry.
+func snapshot(fields []Field) ([]Field, error) {
+	s := make([]Field, len(fields))
+	for i, f := range fields {
+		v, err := snapshotValue(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		s[i] = Field{f.Label, v}
+	}
+	return s, nil
+}
+
+// snapshotValue is v, if it doesn’t refer to anything that may be modified,
+// and the snapshot of what v writes, otherwise.
+func snapshotValue(v Value) (Value, error) {
+	switch v := v.(type) {
+	case value.Bool, value.Caller, value.Duration, value.Float64, value.Int,
+		value.Int64, value.Redacted, value.Stack, value.String, value.Time:
+		return v, nil
+	case Field:
+		fv, err := snapshotValue(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return Field{v.Label, fv}, nil
+	case value.Error:
+		if v.Err == nil {
+			return v, nil
+		}
+		ev, err := snapshotValue(value.Func(v.Write))
+		if err != nil {
+			return nil, err
+		}
+		return value.Error{Err: snapshotError{errorMessage(v.Err), ev}}, nil
+	}
+	var w snapshotWriter
+	if err := v.Write(&w); err != nil {
+		return nil, err
+	}
+	return w.value(), nil
+}
+
+// errorMessage is err.Error(), or “<nil>”, if that panics, as it does for nil
+// pointers to errors, which is what value.Error writes in that case.
+func errorMessage(err error) (message string) {
+	defer func() {
+		if recover() != nil {
+			message = "<nil>"
+		}
+	}()
+	return err.Error()
+}
+
+// snapshotError is the error of the snapshot of a value.Error.
+type snapshotError struct {
+	message string
+	value   Value
+}
+
+func (e snapshotError) Error() string {
+	return e.message
+}
+
+func (e snapshotError) LogValue() value.Value {
+	return e.value
+}
+
+// snapshotValues is a snapshot of a Value that wrote other than exactly one
+// value, which it writes in turn.
+type snapshotValues []Value
+
+func (s snapshotValues) Write(w value.Writer) error {
+	for _, v := range s {
+		if err := v.Write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotWriter records what’s written to it as Values that it owns.
+type snapshotWriter struct {
+	values []Value
+}
+
+func (w *snapshotWriter) add(v Value) error {
+	w.values = append(w.values, v)
+	return nil
+}
+
+func (w *snapshotWriter) Bool(b bool) error {
+	return w.add(value.Bool(b))
+}
+
+func (w *snapshotWriter) Float64(f float64) error {
+	return w.add(value.Float64(f))
+}
+
+func (w *snapshotWriter) Duration(d time.Duration) error {
+	return w.add(value.Duration(d))
+}
+
+func (w *snapshotWriter) Time(t time.Time) error {
+	return w.add(value.Time{Value: t})
+}
+
+func (w *snapshotWriter) Binary(b []byte) error {
+	return w.add(append(value.Bytes(nil), b...))
+}
+
+func (w *snapshotWriter) Int(i int) error {
+	return w.add(value.Int(i))
+}
+
+func (w *snapshotWriter) Int64(i int64) error {
+	return w.add(value.Int64(i))
+}
+
+func (w *snapshotWriter) Reflect(r interface{}) error {
+	if r == nil {
+		return w.add(value.Reflect{})
+	}
+	return w.add(value.Reflect{Value: deepCopy(reflect.ValueOf(r), 0).Interface()})
+}
+
+func (w *snapshotWriter) String(s string) error {
+	return w.add(value.String(s))
+}
+
+func (w *snapshotWriter) Object(f func(value.Writer) error) error {
+	var ow snapshotWriter
+	if err := f(&ow); err != nil {
+		return err
+	}
+	return w.add(append(value.Object{}, ow.values...))
+}
+
+func (w *snapshotWriter) Array(f func(value.Writer) error) error {
+	var aw snapshotWriter
+	if err := f(&aw); err != nil {
+		return err
+	}
+	return w.add(append(value.Array{}, aw.values...))
+}
+
+func (w *snapshotWriter) Field(label string, f func(value.Writer) error) error {
+	var fw snapshotWriter
+	if err := f(&fw); err != nil {
+		return err
+	}
+	return w.add(Field{label, fw.value()})
+}
+
+// value is the Value written to w, if there’s exactly one, and the
+// snapshotValues written to it, otherwise.
+func (w *snapshotWriter) value() Value {
+	if len(w.values) == 1 {
+		return w.values[0]
+	}
+	return snapshotValues(w.values)
+}
+
+// maxCopyDepth is the depth at which deepCopy stops copying, which guards
+// against cyclic data structures.
+const maxCopyDepth = 32
+
+// deepCopy of v, copying what v refers to through pointers, interfaces, maps,
+// and slices, as well as the elements of arrays and exported fields of
+// structs, down to maxCopyDepth.  What’s referred to through unexported fields,
+// channels, and functions, as well as anything beyond maxCopyDepth, is shared
+// with v.
+func deepCopy(v reflect.Value, depth int) reflect.Value {
+	if depth >= maxCopyDepth {
+		return v
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem(), depth+1))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), depth+1))
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for i := v.MapRange(); i.Next(); {
+			c.SetMapIndex(i.Key(), deepCopy(i.Value(), depth+1))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), depth+1))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), depth+1))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i), depth+1))
+			}
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,210 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestAsync(t *testing.T) {
+	t.Run("makes entries in order in the background", func(t *testing.T) {
+		var r log.Recorder
+		a := log.NewAsync(&r, 2, log.Block)
+		b := []byte("c")
+		a.Entry("a", log.Bytes("b", b))
+		b[0] = 'x'
+		a.Named("d").With(log.Int("e", 1)).Entry("f")
+		if err := a.Flush(context.Background()); err != nil {
+			t.Fatalf("a.Flush(…) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{{"b", []byte("c")}}},
+			{Name: "d", Message: "f", Fields: []log.RecordField{{"e", 1}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+		if err := a.Entry("g"); err != log.ErrClosed {
+			t.Errorf("a.Entry(…) after Close = %v, want %v", err, log.ErrClosed)
+		}
+	})
+
+	t.Run("snapshots values", func(t *testing.T) {
+		var r log.Recorder
+		l := &gated{gate: make(chan struct{}), started: make(chan struct{}, 4), r: &r}
+		a := log.NewAsync(l, 1, log.Block)
+		m := &mutable{1}
+		s := map[string][]int{"b": {1}}
+		a.Entry("a",
+			log.Struct("c", m),
+			log.Reflect("d", s),
+			log.Stringer("e", m),
+			log.Lazy("f", func() log.Value { return value.Int(m.N) }),
+			log.Error(fmt.Errorf("g: %w", errors.New("h"))))
+		m.N = 2
+		s["b"][0] = 2
+		close(l.gate)
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(r.Entries(), []log.Record{{Message: "a", Fields: []log.RecordField{
+			{"c", []log.RecordField{{"N", int64(1)}}},
+			{"d", map[string][]int{"b": {1}}},
+			{"e", "1"},
+			{"f", 1},
+			{"error", []log.RecordField{
+				{"message", "g: h"},
+				{"type", "*fmt.wrapError"},
+				{"causes", []interface{}{[]log.RecordField{{"message", "h"}, {"type", "*errors.errorString"}}}},
+			}},
+		}}}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("calls Lazy values before queuing, unless Ruled out", func(t *testing.T) {
+		var (
+			r     log.Recorder
+			rules log.Rules
+		)
+		rules.Set("-b")
+		a := log.NewAsync(log.Ruled(&r, &rules), 2, log.Block)
+		calls := 0
+		lazy := log.Lazy("c", func() log.Value {
+			calls++
+			return value.Int(calls)
+		})
+		a.Named("b").Entry("d", lazy)
+		if calls != 0 {
+			t.Errorf("a.Named(\"b\").Entry(…) with disabled rule called f %d times, want 0", calls)
+		}
+		a.Named("e").Entry("f", lazy)
+		if calls != 1 {
+			t.Errorf("a.Named(\"e\").Entry(…) called f %d times before queuing, want 1", calls)
+		}
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Name: "e", Message: "f", Fields: []log.RecordField{{"c", 1}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	tests := []struct {
+		overflow log.Overflow
+		errs     []error
+		want     []string
+	}{
+		{log.DropNewest, []error{nil, log.ErrDropped}, []string{"blocked", "0"}},
+		{log.DropOldest, []error{nil, nil}, []string{"blocked", "1"}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("overflow %d", tt.overflow), func(t *testing.T) {
+			l := &gated{gate: make(chan struct{}), started: make(chan struct{}, 4)}
+			a := log.NewAsync(l, 1, tt.overflow)
+			a.Entry("blocked")
+			<-l.started
+			for i, want := range tt.errs {
+				if err := a.Entry(fmt.Sprint(i)); err != want {
+					t.Errorf("a.Entry(%q) = %v, want %v", fmt.Sprint(i), err, want)
+				}
+			}
+			if got, want := a.Dropped(), uint64(1); got != want {
+				t.Errorf("a.Dropped() = %d, want %d", got, want)
+			}
+			close(l.gate)
+			if err := a.Close(context.Background()); err != nil {
+				t.Errorf("a.Close(…) = %v, want nil", err)
+			}
+			if diff := cmp.Diff(l.messages, tt.want); diff != "" {
+				t.Errorf("messages diff -got +want\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("blocks when full", func(t *testing.T) {
+		l := &gated{gate: make(chan struct{}), started: make(chan struct{}, 4)}
+		a := log.NewAsync(l, 1, log.Block)
+		a.Entry("a")
+		<-l.started
+		a.Entry("b")
+		made := make(chan error)
+		go func() { made <- a.Entry("c") }()
+		select {
+		case <-made:
+			t.Fatal("a.Entry(…) didn’t block on full queue")
+		case <-time.After(10 * time.Millisecond):
+		}
+		close(l.gate)
+		if err := <-made; err != nil {
+			t.Errorf("a.Entry(…) = %v, want nil", err)
+		}
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(l.messages, []string{"a", "b", "c"}); diff != "" {
+			t.Errorf("messages diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("Flush errors", func(t *testing.T) {
+		failed := errors.New("failed")
+		l := &gated{gate: make(chan struct{}), started: make(chan struct{}, 4), err: failed}
+		a := log.NewAsync(l, 1, log.Block)
+		a.Entry("a")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := a.Flush(ctx); err != context.Canceled {
+			t.Errorf("a.Flush(…) = %v, want %v", err, context.Canceled)
+		}
+		close(l.gate)
+		if err := a.Flush(context.Background()); err != failed {
+			t.Errorf("a.Flush(…) = %v, want %v", err, failed)
+		}
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+	})
+}
+
+// gated is a Logger whose entries wait for gate to be closed before they’re
+// recorded in r, if it isn’t nil.
+type gated struct {
+	gate     chan struct{}
+	started  chan struct{}
+	r        *log.Recorder
+	err      error
+	messages []string
+}
+
+func (g *gated) Entry(message string, fields ...log.Field) error {
+	g.started <- struct{}{}
+	<-g.gate
+	g.messages = append(g.messages, message)
+	if g.r != nil {
+		g.r.Entry(message, fields...)
+	}
+	return g.err
+}
+
+func (g *gated) Named(string) log.Logger      { return g }
+func (g *gated) With(...log.Field) log.Logger { return g }
+
+type mutable struct {
+	N int
+}
+
+func (m *mutable) String() string {
+	return fmt.Sprint(m.N)
+}
@@ -0,0 +1,209 @@
+package log
+
+import (
+	"bytes"
+	stdlog "log"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Bridge of lines written by code that doesn’t use this package, such as code
+// that writes through the standard library’s log package, to entries.
+type Bridge struct {
+	// Name of the Logger that entries are made to, as given to Named.
+	Name string
+
+	// Source of the lines, written as a “source” Field of each entry, unless
+	// it’s empty.
+	Source string
+
+	// Parse a suffix of key-value pairs, such as “a=1 b="c d"”, of each line
+	// into Fields.
+	//
+	// A pair is a key of graphic characters other than equals sign, U+003D,
+	// and quotation mark, U+0022, followed by an equals sign and a value,
+	// which is either a, possibly empty, sequence of characters other than
+	// space, U+0020, or a string quoted as by strconv.Quote.  Pairs are
+	// separated by spaces.  The longest sequence of pairs that ends the line
+	// is written as String Fields, in order, and removed from the line, along
+	// with any spaces preceding them, leaving the message.
+	Parse bool
+}
+
+// maxLine is the length at which an incomplete line is made into an entry.
+const maxLine = 64 << 10
+
+// LineWriter is an io.Writer that makes an entry of each line written to it,
+// as described by a Bridge.
+//
+// A line is terminated by a line feed, U+000A, which is removed, along with
+// any preceding carriage return, U+000D.  Empty lines are ignored.  An
+// incomplete line is held until it’s completed, Flush is called, or it grows
+// beyond 64 KiB.
+//
+// A LineWriter is safe for concurrent use, but lines written concurrently in
+// more than one call to Write each may be interleaved.
+type LineWriter struct {
+	logger Logger
+	b      Bridge
+	mu     sync.Mutex
+	line   []byte
+}
+
+// NewLineWriter is a new LineWriter that makes entries to l as described by
+// b.
+func NewLineWriter(l Logger, b Bridge) *LineWriter {
+	l = l.Named(b.Name)
+	if b.Source != "" {
+		l = l.With(String("source", b.Source))
+	}
+	return &LineWriter{logger: l, b: b}
+}
+
+// Write p, making an entry of each line that it completes.
+//
+// Errors with the first error that making an entry results in, but p is
+// always written in full.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := len(p)
+	var err error
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.line = append(w.line, p...)
+			if len(w.line) >= maxLine {
+				err = w.entry(err)
+			}
+			break
+		}
+		w.line = append(w.line, p[:i]...)
+		err = w.entry(err)
+		p = p[i+1:]
+	}
+	return n, err
+}
+
+// Flush makes an entry of any incomplete line.
+//
+// Errors if making the entry does.
+func (w *LineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.entry(nil)
+}
+
+// entry makes an entry of w.line, if it isn’t empty, and resets it, returning
+// err, if it isn’t nil, or any error that making the entry results in.
+func (w *LineWriter) entry(err error) error {
+	line := string(bytes.TrimSuffix(w.line, []byte{'\r'}))
+	w.line = w.line[:0]
+	if line == "" {
+		return err
+	}
+	var fields []Field
+	if w.b.Parse {
+		line, fields = parsePairs(line)
+	}
+	if eerr := w.logger.Entry(line, fields...); err == nil {
+		err = eerr
+	}
+	return err
+}
+
+// StdLogger is a *log.Logger of the standard library that makes an entry to l
+// of each line written to it, as described by b.
+func StdLogger(l Logger, b Bridge) *stdlog.Logger {
+	return stdlog.New(NewLineWriter(l, b), "", 0)
+}
+
+// RedirectStdLog makes the standard logger of the standard library’s log
+// package make an entry to l of each line written to it, as described by b,
+// returning a function that restores its output, prefix, and flags.
+//
+// The prefix and flags are cleared, as the Logger is in charge of what’s
+// written.
+func RedirectStdLog(l Logger, b Bridge) (restore func()) {
+	out, prefix, flags := stdlog.Writer(), stdlog.Prefix(), stdlog.Flags()
+	stdlog.SetOutput(NewLineWriter(l, b))
+	stdlog.SetPrefix("")
+	stdlog.SetFlags(0)
+	return func() {
+		stdlog.SetOutput(out)
+		stdlog.SetPrefix(prefix)
+		stdlog.SetFlags(flags)
+	}
+}
+
+// parsePairs parses the suffix of key-value pairs of line, as described by
+// Bridge.Parse, returning the rest of line and the pairs as Fields.
+func parsePairs(line string) (string, []Field) {
+	var (
+		fields []Field
+		rest   = len(line)
+	)
+	for i := 0; i < len(line); {
+		if line[i] == ' ' {
+			i++
+			continue
+		}
+		start := i
+		key, value, end, ok := parsePair(line, i)
+		if !ok {
+			fields = fields[:0]
+			rest = len(line)
+			for end < len(line) && line[end] != ' ' {
+				end++
+			}
+		} else {
+			if len(fields) == 0 {
+				rest = start
+			}
+			fields = append(fields, String(key, value))
+		}
+		i = end
+	}
+	if len(fields) == 0 {
+		return line, nil
+	}
+	return strings.TrimRight(line[:rest], " "), fields
+}
+
+// parsePair parses a key-value pair beginning at line[i], returning its key,
+// value, and end.  If there’s no pair at line[i], end is where parsing stopped.
+func parsePair(line string, i int) (key, value string, end int, ok bool) {
+	start := i
+	for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '"' {
+		r, n := utf8.DecodeRuneInString(line[i:])
+		if !unicode.IsGraphic(r) || r == utf8.RuneError && n == 1 {
+			return "", "", i, false
+		}
+		i += n
+	}
+	if i == start || i == len(line) || line[i] != '=' {
+		return "", "", i, false
+	}
+	key = line[start:i]
+	i++
+	if i < len(line) && line[i] == '"' {
+		q, err := strconv.QuotedPrefix(line[i:])
+		if err != nil {
+			return "", "", i, false
+		}
+		end = i + len(q)
+		if end < len(line) && line[end] != ' ' {
+			return "", "", end, false
+		}
+		value, _ = strconv.Unquote(q)
+		return key, value, end, true
+	}
+	end = i
+	for end < len(line) && line[end] != ' ' {
+		end++
+	}
+	return key, line[i:end], end, true
+}
@@ -0,0 +1,115 @@
+package log_test
+
+import (
+	"errors"
+	"fmt"
+	stdlog "log"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+)
+
+func TestLineWriter(t *testing.T) {
+	var r log.Recorder
+	w := log.NewLineWriter(&r, log.Bridge{Name: "a", Source: "b"})
+	for _, s := range []string{"c\nd", "e\r\n\n", "f"} {
+		if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+			t.Errorf("w.Write(%q) = %d, %v, want %d, nil", s, n, err, len(s))
+		}
+	}
+	if got := len(r.Entries()); got != 2 {
+		t.Errorf("len(r.Entries()) = %d before Flush, want 2", got)
+	}
+	if err := w.Flush(); err != nil {
+		t.Errorf("w.Flush() = %v, want nil", err)
+	}
+	source := []log.RecordField{{"source", "b"}}
+	want := []log.Record{
+		{Name: "a", Message: "c", Fields: source},
+		{Name: "a", Message: "de", Fields: source},
+		{Name: "a", Message: "f", Fields: source},
+	}
+	if diff := cmp.Diff(r.Entries(), want); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+
+	t.Run("long line", func(t *testing.T) {
+		var r log.Recorder
+		w := log.NewLineWriter(&r, log.Bridge{})
+		w.Write([]byte(strings.Repeat("a", 64<<10)))
+		if got := len(r.Entries()); got != 1 {
+			t.Errorf("len(r.Entries()) = %d, want 1", got)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		err := errors.New("a")
+		w := log.NewLineWriter(failing{err}, log.Bridge{})
+		if n, got := w.Write([]byte("b\nc\n")); n != 4 || got != err {
+			t.Errorf(`w.Write("b\nc\n") = %d, %v, want 4, %v`, n, got, err)
+		}
+	})
+}
+
+func TestLineWriterParse(t *testing.T) {
+	tests := []struct {
+		line    string
+		message string
+		fields  []log.RecordField
+	}{
+		{"a", "a", nil},
+		{"a b=c", "a", []log.RecordField{{"b", "c"}}},
+		{"a  b=c d= e=\"f g\\\"\"", "a", []log.RecordField{{"b", "c"}, {"d", ""}, {"e", `f g"`}}},
+		{"a=b c", "a=b c", nil},
+		{"a=b c d=e", "a=b c", []log.RecordField{{"d", "e"}}},
+		{"a=b", "", []log.RecordField{{"a", "b"}}},
+		{`a b="c`, `a b="c`, nil},
+		{`a b="c"d`, `a b="c"d`, nil},
+		{`a =b`, `a =b`, nil},
+		{`a b"=c`, `a b"=c`, nil},
+		{"a b=c=d", "a", []log.RecordField{{"b", "c=d"}}},
+	}
+	for _, tt := range tests {
+		var r log.Recorder
+		fmt.Fprintln(log.NewLineWriter(&r, log.Bridge{Parse: true}), tt.line)
+		want := []log.Record{{Message: tt.message, Fields: tt.fields}}
+		if diff := cmp.Diff(r.Entries(), want); diff != "" {
+			t.Errorf("%q: r.Entries() diff -got +want\n%s", tt.line, diff)
+		}
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var r log.Recorder
+	log.StdLogger(&r, log.Bridge{Name: "a", Parse: true}).Printf("b c=%d", 1)
+	want := []log.Record{{Name: "a", Message: "b", Fields: []log.RecordField{{"c", "1"}}}}
+	if diff := cmp.Diff(r.Entries(), want); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	out, prefix := stdlog.Writer(), stdlog.Prefix()
+	defer func() {
+		stdlog.SetOutput(out)
+		stdlog.SetPrefix(prefix)
+	}()
+	var r log.Recorder
+	var b strings.Builder
+	stdlog.SetOutput(&b)
+	stdlog.SetPrefix("p ")
+	restore := log.RedirectStdLog(&r, log.Bridge{Source: "stdlog"})
+	stdlog.Print("a")
+	restore()
+	stdlog.Print("b")
+	want := []log.Record{{Message: "a", Fields: []log.RecordField{{"source", "stdlog"}}}}
+	if diff := cmp.Diff(r.Entries(), want); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+	if got := b.String(); !strings.HasPrefix(got, "p ") || !strings.HasSuffix(got, "b\n") {
+		t.Errorf("standard logger wrote %q after restore, want “p …b\\n”", got)
+	}
+}
@@ -0,0 +1,100 @@
+package log
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/now/x/log/value"
+)
+
+// WithCaller is WithCallerSkip(ctx, 0).
+func WithCaller(ctx context.Context) context.Context {
+	return WithCallerSkip(ctx, 0)
+}
+
+// WithCallerSkip is a new Context based on ctx with a Logger that adds a Field
+// “caller” with a value.Caller of where each entry was made before the given
+// fields, and then delegates to the Logger in ctx.
+//
+// Where an entry was made is the first stack frame outside of this package,
+// which includes functions such as Entry, after skipping skip further frames.
+// A positive skip is thus useful for helper functions that make entries on
+// behalf of their callers.  The same applies to Loggers created by the new
+// Logger’s Named and With methods.
+func WithCallerSkip(ctx context.Context, skip int) context.Context {
+	return Using(ctx, callerLogger{in(ctx), skip})
+}
+
+// Stack Field with label and a value.Stack of the calling goroutine’s stack,
+// beginning with the caller of Stack.
+//
+// The JSON and zap Loggers conventionally use “stacktrace” as label.
+func Stack(label string) Field {
+	return Field{label, callers(2)}
+}
+
+type callerLogger struct {
+	logger Logger
+	skip   int
+}
+
+func (c callerLogger) Entry(message string, fields ...Field) error {
+	l, fields := c.bind(fields)
+	return l.Entry(message, fields...)
+}
+
+func (c callerLogger) bind(fields []Field) (Logger, []Field) {
+	return c.logger, append([]Field{{"caller", c.caller()}}, fields...)
+}
+
+func (c callerLogger) Named(name string) Logger {
+	if name == "" {
+		return c
+	}
+	return callerLogger{c.logger.Named(name), c.skip}
+}
+
+func (c callerLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return c
+	}
+	return callerLogger{c.logger.With(fields...), c.skip}
+}
+
+// caller is the first frame outside of this package after c.skip further
+// frames, or the zero value.Caller if there’s no such frame.
+func (c callerLogger) caller() value.Caller {
+	skip := c.skip
+	for _, f := range callers(3) {
+		if strings.HasPrefix(f.Function, packagePrefix) {
+			continue
+		} else if skip > 0 {
+			skip--
+			continue
+		}
+		return value.Caller(f)
+	}
+	return value.Caller{}
+}
+
+// callers is the stack of the calling goroutine after skipping skip frames,
+// where 0 identifies the frame of callers itself.
+func callers(skip int) value.Stack {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(skip+1, pcs)
+		if n < len(pcs) {
+			return value.Frames(runtime.CallersFrames(pcs[:n]))
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+}
+
+// packagePrefix prefixes the names of the functions of this package, that is,
+// the import path of this package followed by a period.
+var packagePrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(Entry).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
@@ -0,0 +1,87 @@
+package log_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+)
+
+func TestWithCaller(t *testing.T) {
+	t.Run("adds caller of Entry", func(t *testing.T) {
+		var r log.Recorder
+		ctx := log.WithCaller(log.Using(context.Background(), &r))
+		pc, file, line, _ := runtime.Caller(0)
+		function := runtime.FuncForPC(pc).Name()
+		log.Entry(ctx, "a", log.Int("b", 1))
+		log.In(ctx).Named("c").With(log.Int("d", 2)).Entry("e")
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{{"caller", caller(function, file, line+2)}, {"b", 1}}},
+			{Name: "c", Message: "e", Fields: []log.RecordField{{"d", 2}, {"caller", caller(function, file, line+3)}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("skips helpers", func(t *testing.T) {
+		var r log.Recorder
+		ctx := log.WithCallerSkip(log.Using(context.Background(), &r), 1)
+		pc, file, line, _ := runtime.Caller(0)
+		function := runtime.FuncForPC(pc).Name()
+		helper(ctx, "a")
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{{"caller", caller(function, file, line+2)}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("resolves caller before Async queues", func(t *testing.T) {
+		var r log.Recorder
+		a := log.NewAsync(log.In(log.WithCaller(log.Using(context.Background(), &r))), 1, log.Block)
+		pc, file, line, _ := runtime.Caller(0)
+		function := runtime.FuncForPC(pc).Name()
+		a.Named("b").Entry("c")
+		if err := a.Close(context.Background()); err != nil {
+			t.Errorf("a.Close(…) = %v, want nil", err)
+		}
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Name: "b", Message: "c", Fields: []log.RecordField{{"caller", caller(function, file, line+2)}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+}
+
+func helper(ctx context.Context, message string) {
+	log.Entry(ctx, message)
+}
+
+func caller(function, file string, line int) []log.RecordField {
+	return []log.RecordField{
+		{"function", function},
+		{"file", file},
+		{"line", line},
+	}
+}
+
+func TestStack(t *testing.T) {
+	var r log.Recorder
+	r.Entry("a", log.Stack("stacktrace"))
+	v, ok := r.Entries()[0].Value("stacktrace")
+	if !ok {
+		t.Fatalf(`r.Entries()[0].Value("stacktrace") = _, false, want _, true`)
+	}
+	frames, ok := v.([]interface{})
+	if !ok || len(frames) == 0 {
+		t.Fatalf(`r.Entries()[0].Value("stacktrace") = %#v, want non-empty []interface{}`, v)
+	}
+	first := frames[0].([]log.RecordField)
+	if got := first[0].Value.(string); !strings.HasSuffix(got, ".TestStack") {
+		t.Errorf("first frame function = %#v, want TestStack", got)
+	}
+}
@@ -0,0 +1,123 @@
+package log
+
+import (
+	"io"
+	"os"
+
+	"github.com/now/x/log/value"
+)
+
+// Console is a Logger that writes entries to w in the layout of the Logger
+// added by Testing, which is suitable for reading by humans.
+//
+// Each entry is written in a single call to w.Write and followed by a line
+// feed, U+000A.  Lines of fields are indented by four spaces, U+0020, in
+// addition to the indentation described by Testing.  Nothing is written if
+// writing a field errors.
+//
+// If color is true, the name, the message, and the values of any value.Error
+// Fields are colored with ANSI escape sequences.  Use Colorable(w) to
+// determine whether w supports it.
+//
+// Writes to w are serialized, so the Logger and any Logger derived from it by
+// Named or With are safe for concurrent use.
+func Console(w io.Writer, color bool) Logger {
+	return &consoleLogger{w: &syncWriter{w: w}, color: color}
+}
+
+// Colorable is true if w is a terminal and the environment variable NO_COLOR
+// isn’t set to a non-empty string.
+func Colorable(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && isTerminal(f.Fd())
+}
+
+// ANSI escape sequences used by consoleLogger.
+const (
+	colorName    = "\x1b[36m"
+	colorMessage = "\x1b[1m"
+	colorError   = "\x1b[31m"
+	colorReset   = "\x1b[0m"
+)
+
+type consoleLogger struct {
+	w      *syncWriter
+	color  bool
+	name   string
+	fields []Field
+}
+
+func (l *consoleLogger) Entry(message string, fields ...Field) error {
+	w := testingWriters.Get().(*testingWriter)
+	defer func() {
+		w.reset()
+		testingWriters.Put(w)
+	}()
+
+	w.indention = 4
+
+	if l.name != "" {
+		l.colored(w, colorName, func() { w.string(l.name) })
+		w.bytes(": ")
+	}
+
+	l.colored(w, colorMessage, func() { w.string(message) })
+
+	for _, fs := range [][]Field{l.fields, fields} {
+		for _, f := range fs {
+			if err := l.field(w, f); err != nil {
+				return err
+			}
+		}
+	}
+
+	w.byte('\n')
+	_, err := l.w.Write(w.b)
+	return err
+}
+
+func (l *consoleLogger) field(w *testingWriter, f Field) error {
+	if _, ok := f.Value.(value.Error); !ok || !l.color {
+		return f.Write(w)
+	}
+	return w.Field(f.Label, func(vw value.Writer) error {
+		w.color = colorError
+		err := f.Value.Write(vw)
+		if w.color == "" {
+			w.bytes(colorReset)
+		}
+		w.color = ""
+		return err
+	})
+}
+
+func (l *consoleLogger) colored(w *testingWriter, color string, f func()) {
+	if !l.color {
+		f()
+		return
+	}
+	w.bytes(color)
+	f()
+	w.bytes(colorReset)
+}
+
+func (l *consoleLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	c := *l
+	c.name = JoinName(l.name, name)
+	return &c
+}
+
+func (l *consoleLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	c := *l
+	c.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	return &c
+}
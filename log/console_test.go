@@ -0,0 +1,58 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/now/x/log"
+)
+
+func TestConsole(t *testing.T) {
+	tests := []struct {
+		color bool
+		want  string
+	}{
+		{false, "a.b: c\n    d: 1\n    e: f\n       g\n    error: h\n"},
+		{true, "\x1b[36ma.b\x1b[0m: \x1b[1mc\x1b[0m\n    d: 1\n    e: f\n       g\n    error: \x1b[31mh\x1b[0m\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		log.Console(&b, tt.color).Named("a").With(log.Int("d", 1)).Named("b").Entry("c", log.String("e", "f\ng"), log.Error(errors.New("h")))
+		if got := b.String(); got != tt.want {
+			t.Errorf("log.Console(…, %v).….Entry(…) wrote %q, want %q", tt.color, got, tt.want)
+		}
+	}
+}
+
+func TestConsoleErrorObject(t *testing.T) {
+	var b bytes.Buffer
+	log.Console(&b, false).Entry("a", log.Error(fmt.Errorf("b: %w", errors.New("c"))))
+	want := "a\n    error:\n           message: b: c\n           type: *fmt.wrapError\n           causes: [\n                   message: c\n                   type: *errors.errorString]\n"
+	if got := b.String(); got != want {
+		t.Errorf("log.Console(…).Entry(…) wrote %q, want %q", got, want)
+	}
+}
+
+func TestColorable(t *testing.T) {
+	var b bytes.Buffer
+	if log.Colorable(&b) {
+		t.Errorf("log.Colorable(&bytes.Buffer{}) = true, want false")
+	}
+	f, err := os.CreateTemp(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if log.Colorable(f) {
+		t.Errorf("log.Colorable(regular file) = true, want false")
+	}
+	if f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0); err == nil {
+		defer f.Close()
+		if log.Colorable(f) {
+			t.Errorf("log.Colorable(%s) = true, want false", os.DevNull)
+		}
+	}
+}
@@ -68,14 +68,53 @@
 // given Context with a new Logger resulting from naming the existing Logger in
 // the the given Context.  With() returns a new Context based on a given Context
 // with a new Logger resulting from providing additional meta-data to the
-// existing Logger in the given Context.
+// existing Logger in the given Context.  Entries made with Entry(), or to a
+// Logger accessed with In(), also add any meta-data extracted from the given
+// Context by Extractors added to it with Extracting(), such as request IDs
+// added by middleware.
+//
+// Building upon these, Timed() and Start() add log entries with the duration
+// of an operation, as measured by the Clock of the given Context, and
+// Timestamping() adds a timestamp from the Clock of the given Context to each
+// log entry.
+//
+// Beyond the Loggers added by Nop() and Testing(), there are Loggers meant for
+// production use that can be added with Using().  JSON() creates a Logger that
+// writes each log entry as a line of JSON to an io.Writer, which is suitable
+// for processing by machines.  Logfmt() creates a Logger that writes each log
+// entry as a line of logfmt key-value pairs, which is suitable for processing
+// by both machines and humans.  Console() creates a Logger that writes each log
+// entry in the same layout as the Logger added by Testing(), optionally
+// colored, which is suitable for reading by humans during development.  Any of
+// them can be wrapped by NewAsync(), which creates a Logger that makes log
+// entries in the background, so that a slow io.Writer doesn’t hold up the
+// caller.  Tee() creates a Logger that makes log entries to several Loggers,
+// each of which can be restricted to the entries that it cares about with
+// Filter().  Ruled() creates a Logger that can be enabled and disabled at
+// runtime by Rules over the names of Loggers, while Sample() creates a Logger
+// that limits how many log entries with the same message are made per interval.
+// Redact() creates a Logger that keeps secrets out of log entries by redacting
+// Fields based on their labels and values.  Loggers for other destinations,
+// such as syslog, the systemd journal, Graylog, and OpenTelemetry collectors,
+// are found in subpackages, as is a rotating file for Loggers that write to an
+// io.Writer.
+//
+// Code that doesn’t use this package can still make entries through a Bridge:
+// a LineWriter is an io.Writer, StdLogger is a *log.Logger of the standard
+// library, and RedirectStdLog redirects the standard logger, all making an
+// entry of each line written to them.
+//
+// For testing, a Recorder is a Logger that records log entries as Records
+// that can be inspected and compared against wanted results.
 package log
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"github.com/now/x/log/value"
@@ -101,12 +140,32 @@ func Nop(ctx context.Context) context.Context {
 // Logger, then the given fields.  The format of each field is "\n" LABEL ": "
 // VALUE.  The format of VALUE depends on its type.
 //
+// A value.Array is formatted as "[" ELEMENTS "]", where ELEMENTS are its
+// elements, separated by ", ".  Any fields among the elements, including the
+// fields of a value.Object, are formatted as the fields of a value.Object, and
+// the elements following them begin on a line of their own, indented as the
+// fields.
+//
+// A value.Bool is formatted as true or false.
+//
+// A value.Bytes is formatted as its standard base64 encoding.
+//
+// A value.Duration is formatted as by time.Duration.String.
+//
+// A value.Float64 f is formatted as by strconv.FormatFloat(f, 'g', -1, 64).
+//
 // A value.Int64 is formatted as an integer in base ten.  Negative values are
 // prefixed by a hyphen-minus, U+002D.
 //
+// A value.Object is formatted as its fields, with each line of them indented by
+// i spaces, U+0020, where i = len(LABEL) + 2, if there’s a LABEL, i = 0,
+// otherwise.  There’s no space after the colon of the enclosing field.
+//
 // A value.Reflect’s Value r is replaced by s = fmt.Sprintf("%+v", r) and s is
 // formatted as a value.String.
 //
+// A value.Time is formatted in the time.RFC3339Nano layout.
+//
 // A value.String is formatted as is, except that line feed, U+000A, is followed
 // by i spaces, U+0020, where i = len(LABEL) + 2, if there’s a LABEL, i = 0,
 // otherwise, and any rune c below U+0020 except for line feed, U+000A, is
@@ -122,9 +181,18 @@ func Using(ctx context.Context, l Logger) context.Context {
 
 // In is the Logger in ctx.
 //
+// If any Extractors have been added to ctx with Extracting, the Logger is
+// wrapped so that the Fields they extract from ctx precede the fields of each
+// entry made to it or to any Logger derived from it.
+//
 // In is nil if no Logger has been added to ctx with Nop(ctx), Testing(ctx,
 // testing.T), or Using(ctx, Logger).
 func In(ctx context.Context) Logger {
+	return extracting(ctx, in(ctx))
+}
+
+// in is the Logger in ctx, without any Extractors.
+func in(ctx context.Context) Logger {
 	return ctx.Value(Key).(Logger)
 }
 
@@ -139,12 +207,12 @@ func Entry(ctx context.Context, message string, fields ...Field) error {
 
 // Named is ctxʹ ≈ ctx such that In(ctxʹ) = l.Named(name), where l = In(ctx).
 func Named(ctx context.Context, name string) context.Context {
-	return Using(ctx, In(ctx).Named(name))
+	return Using(ctx, in(ctx).Named(name))
 }
 
 // With is ctxʹ ≈ ctx such that In(ctxʹ) = l.With(fields...), where l = In(ctx).
 func With(ctx context.Context, fields ...Field) context.Context {
-	return Using(ctx, In(ctx).With(fields...))
+	return Using(ctx, in(ctx).With(fields...))
 }
 
 // Key of Logger in context.Context.
@@ -191,9 +259,7 @@ func (t *testingLogger) Entry(message string, fields ...Field) (err error) {
 
 	w := testingWriters.Get().(*testingWriter)
 	defer func() {
-		w.b = w.b[:0]
-		w.indention = 0
-		w.separate = false
+		w.reset()
 		testingWriters.Put(w)
 		if err != nil {
 			t.t.Log(fmt.Sprintf("write error: %v", err))
@@ -285,6 +351,20 @@ type testingWriter struct {
 	b         []byte
 	indention int
 	separate  bool
+	spaced    bool
+	inArray   bool
+	lined     bool   // lined is true if a field ended the last line of an array.
+	color     string // color is written after the next separator, if any.
+}
+
+func (w *testingWriter) reset() {
+	w.b = w.b[:0]
+	w.indention = 0
+	w.separate = false
+	w.spaced = false
+	w.inArray = false
+	w.lined = false
+	w.color = ""
 }
 
 func (w *testingWriter) Int(i int) error {
@@ -297,6 +377,32 @@ func (w *testingWriter) Int64(i int64) error {
 	return nil
 }
 
+func (w *testingWriter) Bool(b bool) error {
+	w.separator()
+	w.b = strconv.AppendBool(w.b, b)
+	return nil
+}
+
+func (w *testingWriter) Float64(f float64) error {
+	w.separator()
+	w.b = strconv.AppendFloat(w.b, f, 'g', -1, 64)
+	return nil
+}
+
+func (w *testingWriter) Duration(d time.Duration) error {
+	return w.String(d.String())
+}
+
+func (w *testingWriter) Time(t time.Time) error {
+	w.separator()
+	w.b = t.AppendFormat(w.b, time.RFC3339Nano)
+	return nil
+}
+
+func (w *testingWriter) Binary(b []byte) error {
+	return w.String(base64.StdEncoding.EncodeToString(b))
+}
+
 func (w *testingWriter) Reflect(r interface{}) error {
 	return w.String(fmt.Sprintf("%+v", r))
 }
@@ -307,23 +413,57 @@ func (w *testingWriter) String(s string) error {
 	return nil
 }
 
+func (w *testingWriter) Object(f func(value.Writer) error) error {
+	w.spaced = true
+	err := f(w)
+	w.separate = true
+	return err
+}
+
+func (w *testingWriter) Array(f func(value.Writer) error) error {
+	inArray := w.inArray
+	w.separator()
+	w.byte('[')
+	w.inArray = true
+	w.separate = false
+	w.spaced = true
+	err := f(w)
+	w.byte(']')
+	w.inArray = inArray
+	w.lined = false
+	w.separate = true
+	return err
+}
+
 func (w *testingWriter) Field(label string, f func(value.Writer) error) error {
 	w.lineFeed()
 	w.string(label)
-	w.bytes(": ")
+	w.byte(':')
 	w.separate = false
+	w.spaced = false
 	n := len(label) + 2
 	w.indention += n
 	err := f(w)
 	w.indention -= n
+	w.separate = true
+	w.lined = w.inArray
 	return err
 }
 
 func (w *testingWriter) separator() {
-	if w.separate {
+	switch {
+	case w.lined:
+		w.lineFeed()
+	case w.separate:
 		w.bytes(", ")
-	} else {
-		w.separate = true
+	case !w.spaced:
+		w.byte(' ')
+		w.spaced = true
+	}
+	w.separate = true
+	if w.color != "" {
+		w.bytes(w.color)
+		w.color = ""
 	}
 }
 
@@ -355,6 +495,7 @@ func (w *testingWriter) string(s string) {
 }
 
 func (w *testingWriter) lineFeed() {
+	w.lined = false
 	w.byte('\n')
 	for i := 0; i < w.indention; i++ {
 		w.byte(' ')
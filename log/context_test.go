@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/now/x/log"
+	"github.com/now/x/log/value"
 	xtesting "github.com/now/x/testing"
 )
 
@@ -47,6 +49,14 @@ func TestNop(t *testing.T) {
 	if err := log.In(log.Nop(context.Background())).With(log.Int64("a", 1)).Entry("bc"); err != nil {
 		t.Errorf("log.In(log.Nop(…)).Named(\"a\").Entry(\"bc\") = %v, want nil", err)
 	}
+
+	lazy := log.Lazy("a", func() log.Value {
+		t.Errorf("log.In(log.Nop(…)).With(log.Lazy(…)).Entry(\"bc\", log.Lazy(…)) evaluated log.Lazy(…)")
+		return value.Int(1)
+	})
+	if err := log.In(log.Nop(context.Background())).With(lazy).Entry("bc", lazy); err != nil {
+		t.Errorf("log.In(log.Nop(…)).With(log.Lazy(…)).Entry(\"bc\", log.Lazy(…)) = %v, want nil", err)
+	}
 }
 
 func TestTesting(t *testing.T) {
@@ -104,8 +114,16 @@ func TestTesting(t *testing.T) {
 			field log.Field
 			want  string
 		}{
+			{log.Bool("ok", true), "ok: true"},
+			{log.Bytes("data", []byte("abc")), "data: YWJj"},
+			{log.Duration("elapsed", time.Second), "elapsed: 1s"},
+			{log.Float64("ratio", 0.5), "ratio: 0.5"},
 			{log.Int64("ID", 1), "ID: 1"},
 			{log.Int64("neg", -1), "neg: -1"},
+			{log.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), "at: 2022-03-09T19:51:00Z"},
+			{log.Object("object", log.Int("a", 1), log.Object("b", log.String("c", "d\ne"))), "object:\n        a: 1\n        b:\n           c: d\n              e"},
+			{log.Array("array", value.Int(1), value.String("b")), "array: [1, b]"},
+			{log.Array("fields", value.Int(1), log.Int("b", 2), value.Object{log.Int("c", 3), log.Array("d", log.Int("e", 4))}, value.String("f")), "fields: [1\n        b: 2\n        c: 3\n        d: [\n           e: 4]\n        f]"},
 			{log.Reflect("values", map[string]int{"a": 1}), "values: map[a:1]"},
 			{log.String("name", "something"), "name: something"},
 			{log.String("lines", "a\nb"), "lines: a\n       b"},
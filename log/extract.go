@@ -0,0 +1,72 @@
+package log
+
+import "context"
+
+// Extractor of Fields from a context.Context, such as a request ID that was
+// added to it by some middleware.
+type Extractor func(context.Context) []Field
+
+// Extracting is ctxʹ ≈ ctx such that entries made to In(ctxʺ), including by
+// Entry(ctxʺ, …), and to any Logger derived from it, also add the Fields
+// extracted by e from ctxʺ, where ctxʺ is ctxʹ or any Context derived from it,
+// including by Named and With.
+//
+// Extractors are run in the order that they were added, each time an entry is
+// made, so they see any values added to ctxʺ after ctxʹ, but not any added to
+// a Context derived from ctxʺ after the Logger was taken with In(ctxʺ).
+func Extracting(ctx context.Context, e Extractor) context.Context {
+	es, _ := ctx.Value(extractorsKey{}).([]Extractor)
+	return context.WithValue(ctx, extractorsKey{}, append(es[:len(es):len(es)], e))
+}
+
+type extractorsKey struct{}
+
+// extracting is l wrapped so that entries made to it add the Fields extracted
+// from ctx, if any Extractors have been added to ctx.
+func extracting(ctx context.Context, l Logger) Logger {
+	if es, _ := ctx.Value(extractorsKey{}).([]Extractor); len(es) == 0 {
+		return l
+	}
+	return extractingLogger{l, ctx}
+}
+
+type extractingLogger struct {
+	logger Logger
+	ctx    context.Context
+}
+
+func (l extractingLogger) Entry(message string, fields ...Field) error {
+	return l.logger.Entry(message, extract(l.ctx, fields)...)
+}
+
+func (l extractingLogger) bind(fields []Field) (Logger, []Field) {
+	return l.logger, extract(l.ctx, fields)
+}
+
+func (l extractingLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	return extractingLogger{l.logger.Named(name), l.ctx}
+}
+
+func (l extractingLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return extractingLogger{l.logger.With(fields...), l.ctx}
+}
+
+// extract is fields preceded by the Fields extracted from ctx by the
+// Extractors added with Extracting.
+func extract(ctx context.Context, fields []Field) []Field {
+	es, _ := ctx.Value(extractorsKey{}).([]Extractor)
+	if len(es) == 0 {
+		return fields
+	}
+	var extracted []Field
+	for _, e := range es {
+		extracted = append(extracted, e(ctx)...)
+	}
+	return append(extracted, fields...)
+}
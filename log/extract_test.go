@@ -0,0 +1,40 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+)
+
+type requestIDKey struct{}
+
+func requestID(ctx context.Context) []log.Field {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return []log.Field{log.String("request", id)}
+	}
+	return nil
+}
+
+func TestExtracting(t *testing.T) {
+	var r log.Recorder
+	ctx := log.Extracting(log.Using(context.Background(), &r), requestID)
+	ctx = log.Extracting(ctx, func(context.Context) []log.Field {
+		return []log.Field{log.Int("tenant", 1)}
+	})
+	log.Entry(ctx, "a")
+	rctx := log.With(log.Named(context.WithValue(ctx, requestIDKey{}, "b"), "c"), log.Int("d", 2))
+	log.Entry(rctx, "e", log.Int("f", 3))
+	log.Entry(log.Using(context.Background(), &r), "g")
+	log.In(rctx).Named("h").Entry("i")
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Message: "a", Fields: []log.RecordField{{"tenant", 1}}},
+		{Name: "c", Message: "e", Fields: []log.RecordField{{"d", 2}, {"request", "b"}, {"tenant", 1}, {"f", 3}}},
+		{Message: "g"},
+		{Name: "c.h", Message: "i", Fields: []log.RecordField{{"d", 2}, {"request", "b"}, {"tenant", 1}}},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
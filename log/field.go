@@ -2,6 +2,7 @@ package log
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/now/x/log/value"
 )
@@ -18,11 +19,36 @@ type Field struct {
 	Value Value
 }
 
+// Array Field with label and value.Array(values).
+func Array(label string, values ...Value) Field {
+	return Field{label, value.Array(values)}
+}
+
+// Bool Field with label and value.Bool(b).
+func Bool(label string, b bool) Field {
+	return Field{label, value.Bool(b)}
+}
+
+// Bytes Field with label and value.Bytes(b).
+func Bytes(label string, b []byte) Field {
+	return Field{label, value.Bytes(b)}
+}
+
+// Duration Field with label and value.Duration(d).
+func Duration(label string, d time.Duration) Field {
+	return Field{label, value.Duration(d)}
+}
+
 // Error Field with Label “error” and value.Error{Err: err}.
 func Error(err error) Field {
 	return Field{"error", value.Error{Err: err}}
 }
 
+// Float64 Field with label and value.Float64(f).
+func Float64(label string, f float64) Field {
+	return Field{label, value.Float64(f)}
+}
+
 // Int Field with label and value.Int(i).
 func Int(label string, i int) Field {
 	return Field{label, value.Int(i)}
@@ -33,6 +59,30 @@ func Int64(label string, i int64) Field {
 	return Field{label, value.Int64(i)}
 }
 
+// Lazy Field with label and a value.Func that writes f().
+//
+// The function f is only called if and when the Field is written, which
+// allows for deferring the work of computing the Value until it’s needed.
+func Lazy(label string, f func() Value) Field {
+	return Field{label, value.Func(func(w value.Writer) error {
+		return f().Write(w)
+	})}
+}
+
+// NamedError Field with label and value.Error{Err: err}.
+func NamedError(label string, err error) Field {
+	return Field{label, value.Error{Err: err}}
+}
+
+// Object Field with label and a value.Object of fields.
+func Object(label string, fields ...Field) Field {
+	o := make(value.Object, len(fields))
+	for i, f := range fields {
+		o[i] = f
+	}
+	return Field{label, o}
+}
+
 // Reflect Field with label and value.Reflect{Value: r}.
 func Reflect(label string, r interface{}) Field {
 	return Field{label, value.Reflect{Value: r}}
@@ -48,6 +98,16 @@ func Stringer(label string, s fmt.Stringer) Field {
 	return Field{label, value.Stringer{Value: s}}
 }
 
+// Struct Field with label and value.Struct{Value: s}.
+func Struct(label string, s interface{}) Field {
+	return Field{label, value.Struct{Value: s}}
+}
+
+// Time Field with label and value.Time{Value: t}.
+func Time(label string, t time.Time) Field {
+	return Field{label, value.Time{Value: t}}
+}
+
 // Write f to w using w.Field(f.Label, f.Value.Write).
 func (f Field) Write(w value.Writer) error {
 	return w.Field(f.Label, f.Value.Write)
@@ -1,6 +1,7 @@
 package log_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ func TestError(t *testing.T) {
 		want string
 	}{
 		{fmt.Errorf("failed"), "error: failed"},
+		{fmt.Errorf("failed: %w", errors.New("cause")), "error: {message: failed: cause; type: *fmt.wrapError; causes: [{message: cause; type: *errors.errorString}]}"},
 	}
 	for _, tt := range tests {
 		expression := fmt.Sprintf("log.Error(%#v).Write(…)", tt.err)
@@ -121,3 +123,199 @@ func TestStringer(t *testing.T) {
 		}
 	}
 }
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		label string
+		v     bool
+		want  string
+	}{
+		{"ok", true, "ok: true"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Bool(%#v, %#v).Write(…)", tt.label, tt.v)
+		var w value.BytesWriter
+		if err := log.Bool(tt.label, tt.v).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		label string
+		v     []byte
+		want  string
+	}{
+		{"data", []byte("abc"), "data: YWJj"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Bytes(%#v, %#v).Write(…)", tt.label, tt.v)
+		var w value.BytesWriter
+		if err := log.Bytes(tt.label, tt.v).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		label string
+		v     time.Duration
+		want  string
+	}{
+		{"elapsed", time.Second, "elapsed: 1s"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Duration(%#v, %#v).Write(…)", tt.label, tt.v)
+		var w value.BytesWriter
+		if err := log.Duration(tt.label, tt.v).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	tests := []struct {
+		label string
+		v     float64
+		want  string
+	}{
+		{"ratio", 0.5, "ratio: 0.5"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Float64(%#v, %#v).Write(…)", tt.label, tt.v)
+		var w value.BytesWriter
+		if err := log.Float64(tt.label, tt.v).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	tests := []struct {
+		label string
+		v     time.Time
+		want  string
+	}{
+		{"at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC), "at: 2022-03-09T19:51:00Z"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Time(%#v, %#v).Write(…)", tt.label, tt.v)
+		var w value.BytesWriter
+		if err := log.Time(tt.label, tt.v).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestObject(t *testing.T) {
+	tests := []struct {
+		label  string
+		fields []log.Field
+		want   string
+	}{
+		{"empty", nil, "empty: {}"},
+		{"object", []log.Field{log.Int("a", 1), log.String("b", "c")}, "object: {a: 1; b: c}"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Object(%#v, %#v...).Write(…)", tt.label, tt.fields)
+		var w value.BytesWriter
+		if err := log.Object(tt.label, tt.fields...).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestArray(t *testing.T) {
+	tests := []struct {
+		label  string
+		values []log.Value
+		want   string
+	}{
+		{"empty", nil, "empty: []"},
+		{"array", []log.Value{value.Int(1), value.String("b")}, "array: [1, b]"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Array(%#v, %#v...).Write(…)", tt.label, tt.values)
+		var w value.BytesWriter
+		if err := log.Array(tt.label, tt.values...).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		label string
+		s     interface{}
+		want  string
+	}{
+		{"user", struct {
+			Name     string `log:"name"`
+			Password string `log:",redact"`
+		}{"a", "b"}, "user: {name: a; Password: [REDACTED]}"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.Struct(%#v, %#v).Write(…)", tt.label, tt.s)
+		var w value.BytesWriter
+		if err := log.Struct(tt.label, tt.s).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestNamedError(t *testing.T) {
+	tests := []struct {
+		label string
+		err   error
+		want  string
+	}{
+		{"cause", fmt.Errorf("failed"), "cause: failed"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("log.NamedError(%#v, %#v).Write(…)", tt.label, tt.err)
+		var w value.BytesWriter
+		if err := log.NamedError(tt.label, tt.err).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	f := log.Lazy("summary", func() log.Value {
+		calls++
+		return value.String("abc")
+	})
+	if calls != 0 {
+		t.Errorf("log.Lazy(\"summary\", f) called f %d times, want 0", calls)
+	}
+	var w value.BytesWriter
+	if err := f.Write(&w); err != nil {
+		t.Errorf("log.Lazy(\"summary\", f).Write(…) = %v, want nil", err)
+	} else if got, want := string(w.Bytes), "summary: abc"; got != want {
+		t.Errorf("log.Lazy(\"summary\", f).Write(…) = %#v, want %#v", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("log.Lazy(\"summary\", f).Write(…) called f %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,326 @@
+// Package gelf contains a Logger that sends entries to a Graylog server as
+// GELF 1.1 messages.
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+	xtime "github.com/now/x/time"
+)
+
+// Config of a Logger.
+type Config struct {
+	// Network and Address of the Graylog server, as used by net.Dial.  Network
+	// is one of “udp”, “udp4”, and “udp6”, in which case each message is sent
+	// as a datagram, chunked, if it doesn’t fit in ChunkSize bytes, or one of
+	// “tcp”, “tcp4”, and “tcp6”, in which case messages are terminated by a
+	// null byte, U+0000.
+	Network string
+	Address string
+
+	// Host of messages, os.Hostname() if empty.
+	Host string
+
+	// Level of messages, a syslog severity, 6, informational, if 0.
+	Level int
+
+	// Compress messages sent over UDP with gzip.  Messages sent over TCP can’t
+	// be compressed.
+	Compress bool
+
+	// ChunkSize is the maximum size in bytes of a datagram sent over UDP,
+	// including the 12 bytes of the header of a chunk, 1420 if ≤ 12.
+	ChunkSize int
+
+	// Clock to timestamp messages with, time.Now if nil.
+	Clock xtime.Clock
+}
+
+// Logger that sends entries to a Graylog server as GELF 1.1 messages.
+//
+// An entry is sent as a JSON object with the members "version", “1.1”,
+// "host", the Host of the Config, "short_message", the message, or “-”, if
+// it’s empty, "timestamp", the time of the entry in seconds since the Unix
+// epoch, "level", the Level of the Config, "_facility", the name of the Logger,
+// if it isn’t empty, and an additional field for each Field, beginning with
+// those added to the Logger, then the given fields.  Facility is deprecated as
+// a member of its own by GELF 1.1, but Graylog stores the additional field as
+// “facility”.
+//
+// The name of an additional field is the label of the Field prefixed by a low
+// line, U+005F, with characters other than letters, digits, low lines, periods,
+// and hyphen-minuses replaced by low lines.  As “_id” is reserved, a Field
+// labeled “id” is named “__id”.  The value of an additional field is the
+// Field’s Value as written by a value.JSONWriter, if that’s a JSON string or
+// number, and that JSON as a JSON string, otherwise, as GELF only allows for
+// strings and numbers.
+//
+// If sending a message fails, the connection is redialed and the message is
+// resent once.
+//
+// A Logger is safe for concurrent use.
+type Logger struct {
+	c      *conn
+	name   string
+	fields []log.Field
+}
+
+// Dial is a new Logger that sends entries to the Graylog server of c.
+//
+// Errors if the Graylog server can’t be dialed.
+func Dial(c Config) (*Logger, error) {
+	if c.Host == "" {
+		c.Host, _ = os.Hostname()
+	}
+	if c.Level == 0 {
+		c.Level = 6
+	}
+	if c.ChunkSize <= chunkHeader {
+		c.ChunkSize = 1420
+	}
+	if c.Clock == nil {
+		c.Clock = xtime.ClockFunc(time.Now)
+	}
+	cn := &conn{c: c}
+	switch c.Network {
+	case "tcp", "tcp4", "tcp6":
+		cn.stream = true
+	}
+	if err := cn.dial(); err != nil {
+		return nil, err
+	}
+	return &Logger{c: cn}, nil
+}
+
+// Entry sends message and fields to the Graylog server.
+//
+// Errors if a field errors when being written or if the message can’t be
+// sent.
+func (l *Logger) Entry(message string, fields ...log.Field) error {
+	m, err := l.c.message(l.name, message, [2][]log.Field{l.fields, fields})
+	if err != nil {
+		return err
+	}
+	return l.c.send(m)
+}
+
+// Named is a new Logger that shares l’s connection.
+func (l *Logger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &Logger{l.c, log.JoinName(l.name, name), l.fields}
+}
+
+// With is a new Logger that shares l’s connection.
+func (l *Logger) With(fields ...log.Field) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{l.c, l.name, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// Close the connection to the Graylog server.
+func (l *Logger) Close() error {
+	return l.c.close()
+}
+
+const (
+	chunkHeader = 12
+	maxChunks   = 128
+)
+
+type conn struct {
+	c      Config
+	stream bool
+
+	mu sync.Mutex
+	nc net.Conn
+}
+
+func (c *conn) dial() error {
+	nc, err := net.Dial(c.c.Network, c.c.Address)
+	if err != nil {
+		return fmt.Errorf("gelf: %w", err)
+	}
+	c.nc = nc
+	return nil
+}
+
+func (c *conn) send(m []byte) error {
+	var ds [][]byte
+	if c.stream {
+		ds = [][]byte{append(m, 0)}
+	} else {
+		if c.c.Compress {
+			var b bytes.Buffer
+			w := gzip.NewWriter(&b)
+			w.Write(m)
+			w.Close()
+			m = b.Bytes()
+		}
+		var err error
+		if ds, err = c.chunks(m); err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil {
+		if err := c.write(ds); err == nil {
+			return nil
+		}
+		c.nc.Close()
+		c.nc = nil
+	}
+	if err := c.dial(); err != nil {
+		return err
+	}
+	if err := c.write(ds); err != nil {
+		c.nc.Close()
+		c.nc = nil
+		return fmt.Errorf("gelf: %w", err)
+	}
+	return nil
+}
+
+func (c *conn) write(ds [][]byte) error {
+	for _, d := range ds {
+		if _, err := c.nc.Write(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunks are the datagrams to send m in, which is m itself, if it fits in a
+// datagram, and GELF chunks of m, otherwise.
+func (c *conn) chunks(m []byte) ([][]byte, error) {
+	if len(m) <= c.c.ChunkSize {
+		return [][]byte{m}, nil
+	}
+	size := c.c.ChunkSize - chunkHeader
+	n := (len(m) + size - 1) / size
+	if n > maxChunks {
+		return nil, fmt.Errorf("gelf: message of %d bytes needs %d chunks, more than %d", len(m), n, maxChunks)
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("gelf: %w", err)
+	}
+	ds := make([][]byte, n)
+	for i := range ds {
+		end := (i + 1) * size
+		if end > len(m) {
+			end = len(m)
+		}
+		d := make([]byte, 0, chunkHeader+end-i*size)
+		d = append(d, 0x1e, 0x0f)
+		d = append(d, id[:]...)
+		d = append(d, byte(i), byte(n))
+		ds[i] = append(d, m[i*size:end]...)
+	}
+	return ds, nil
+}
+
+func (c *conn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc == nil {
+		return nil
+	}
+	err := c.nc.Close()
+	c.nc = nil
+	return err
+}
+
+// message is the GELF message of an entry.
+func (c *conn) message(name, message string, fieldss [2][]log.Field) ([]byte, error) {
+	if message == "" {
+		message = "-"
+	}
+	w := value.JSONWriter{Bytes: make([]byte, 0, 256)}
+	w.Bytes = append(w.Bytes, '{')
+	w.Field("version", value.String("1.1").Write)
+	w.Field("host", value.String(c.c.Host).Write)
+	w.Field("short_message", value.String(message).Write)
+	now := c.c.Clock.Now()
+	w.Field("timestamp", func(value.Writer) error {
+		w.Bytes = appendTimestamp(w.Bytes, now)
+		return nil
+	})
+	w.Field("level", value.Int(c.c.Level).Write)
+	if name != "" {
+		w.Field("_facility", value.String(name).Write)
+	}
+	var v value.JSONWriter
+	for _, fields := range fieldss {
+		for _, f := range fields {
+			// Write the value as that of a Field with an empty label, so that
+			// it’s valid JSON, and then drop the label and colon.
+			v = value.JSONWriter{Bytes: v.Bytes[:0]}
+			if err := v.Field("", f.Value.Write); err != nil {
+				return nil, err
+			}
+			b := v.Bytes[len(`"":`):]
+			w.Field(fieldName(f.Label), func(value.Writer) error {
+				if isStringOrNumber(b) {
+					w.Bytes = append(w.Bytes, b...)
+					return nil
+				}
+				return w.String(string(b))
+			})
+		}
+	}
+	w.Bytes = append(w.Bytes, '}')
+	return w.Bytes, nil
+}
+
+// appendTimestamp appends t to b as seconds since the Unix epoch with
+// microsecond precision.
+func appendTimestamp(b []byte, t time.Time) []byte {
+	us := t.UnixNano() / int64(time.Microsecond)
+	if us < 0 {
+		return strconv.AppendFloat(b, float64(us)/1e6, 'f', 6, 64)
+	}
+	b = strconv.AppendInt(b, us/1e6, 10)
+	b = append(b, '.')
+	frac := strconv.AppendInt(nil, us%1e6, 10)
+	for i := len(frac); i < 6; i++ {
+		b = append(b, '0')
+	}
+	return append(b, frac...)
+}
+
+// fieldName is the name of the additional field of a Field labeled label.
+func fieldName(label string) string {
+	if label == "id" {
+		return "__id"
+	}
+	b := make([]byte, 0, 1+len(label))
+	b = append(b, '_')
+	for i := 0; i < len(label); i++ {
+		switch c := label[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '.', c == '-':
+			b = append(b, c)
+		default:
+			b = append(b, '_')
+		}
+	}
+	return string(b)
+}
+
+// isStringOrNumber is true if b is a JSON string or number.
+func isStringOrNumber(b []byte) bool {
+	return len(b) > 0 && (b[0] == '"' || b[0] == '-' || ('0' <= b[0] && b[0] <= '9'))
+}
@@ -0,0 +1,214 @@
+package gelf_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/gelf"
+	xtime "github.com/now/x/time"
+)
+
+func config(network, address string) gelf.Config {
+	return gelf.Config{
+		Network: network,
+		Address: address,
+		Host:    "host",
+		Clock:   xtime.StoppedAt(time.Date(2022, time.March, 9, 19, 51, 0, 1500, time.UTC)),
+	}
+}
+
+func TestLoggerUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	l, err := gelf.Dial(config("udp", pc.LocalAddr().String()))
+	if err != nil {
+		t.Fatalf("gelf.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	const prefix = `{"version":"1.1","host":"host",`
+	const timestamp = `"timestamp":1646855460.000001,"level":6`
+	tests := []struct {
+		logger  log.Logger
+		message string
+		fields  []log.Field
+		want    string
+	}{
+		{l, "", nil, prefix + `"short_message":"-",` + timestamp + `}`},
+		{
+			l.Named("db").Named("pool"),
+			"a",
+			[]log.Field{log.Int("b", 1), log.String("id", "c"), log.String("d e", "f")},
+			prefix + `"short_message":"a",` + timestamp + `,"_facility":"db.pool","_b":1,"__id":"c","_d_e":"f"}`,
+		},
+		{
+			l.With(log.Bool("g", true)),
+			"h",
+			[]log.Field{log.Object("i", log.Int("j", 2)), log.Float64("k", 0.5), log.Array("l", log.Int("m", 3))},
+			prefix + `"short_message":"h",` + timestamp + `,"_g":"true","_i":"{\"j\":2}","_k":0.5,"_l":"[{\"m\":3}]"}`,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.logger.Entry(tt.message, tt.fields...); err != nil {
+			t.Fatalf("l.Entry(%q, …) = %v, want nil", tt.message, err)
+		}
+		b := make([]byte, 2048)
+		pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := pc.ReadFrom(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b[:n]); got != tt.want {
+			t.Errorf("l.Entry(%q, …) sent %s, want %s", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerUDPChunked(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	c := config("udp", pc.LocalAddr().String())
+	c.Compress = true
+	c.ChunkSize = 64
+	l, err := gelf.Dial(c)
+	if err != nil {
+		t.Fatalf("gelf.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	message := strings.Repeat("abcdefghijklmnopqrstuvwxyz", 20)
+	if err := l.Entry(message, log.String("a", "b")); err != nil {
+		t.Fatalf("l.Entry(…) = %v, want nil", err)
+	}
+
+	var (
+		id     []byte
+		chunks [][]byte
+	)
+	for chunks == nil || len(chunks) < cap(chunks) {
+		b := make([]byte, 2048)
+		pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := pc.ReadFrom(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n > c.ChunkSize {
+			t.Fatalf("len(chunk) = %d, want ≤ %d", n, c.ChunkSize)
+		}
+		b = b[:n]
+		if !bytes.HasPrefix(b, []byte{0x1e, 0x0f}) {
+			t.Fatalf("chunk = %x, want GELF chunk", b)
+		}
+		if chunks == nil {
+			id = b[2:10]
+			chunks = make([][]byte, 0, b[11])
+		}
+		if !bytes.Equal(b[2:10], id) || int(b[10]) != len(chunks) || int(b[11]) != cap(chunks) {
+			t.Fatalf("chunk header = %x, want %x, %d, %d", b[2:12], id, len(chunks), cap(chunks))
+		}
+		chunks = append(chunks, b[12:])
+	}
+	if len(chunks) < 2 {
+		t.Errorf("len(chunks) = %d, want > 1", len(chunks))
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(bytes.Join(chunks, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, map[string]interface{}{
+		"version":       "1.1",
+		"host":          "host",
+		"short_message": message,
+		"timestamp":     1646855460.000001,
+		"level":         6.0,
+		"_a":            "b",
+	}); diff != "" {
+		t.Errorf("l.Entry(…) sent diff -got +want\n%s", diff)
+	}
+}
+
+func TestLoggerUDPTooLarge(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	c := config("udp", pc.LocalAddr().String())
+	c.ChunkSize = 13
+	l, err := gelf.Dial(c)
+	if err != nil {
+		t.Fatalf("gelf.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+	if err := l.Entry(strings.Repeat("a", 128)); err == nil || !strings.HasPrefix(err.Error(), "gelf: ") {
+		t.Errorf("l.Entry(…) = %v, want gelf error", err)
+	}
+}
+
+func TestLoggerTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	l, err := gelf.Dial(config("tcp", ln.Addr().String()))
+	if err != nil {
+		t.Fatalf("gelf.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	c, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	r := bufio.NewReader(c)
+	for _, s := range []string{"a", "b\x00c"} {
+		if err := l.Entry(s); err != nil {
+			t.Fatalf("l.Entry(%q) = %v, want nil", s, err)
+		}
+		b, err := r.ReadBytes(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(b[:len(b)-1], &got); err != nil {
+			t.Fatalf("json.Unmarshal(%s, …) = %v, want nil", b, err)
+		}
+		if got["short_message"] != s {
+			t.Errorf("l.Entry(%q) sent %s, want short_message %q", s, b, s)
+		}
+	}
+}
+
+func TestDialError(t *testing.T) {
+	_, err := gelf.Dial(config("tcp", "127.0.0.1:0"))
+	if err == nil || !strings.HasPrefix(err.Error(), "gelf: ") {
+		t.Errorf("gelf.Dial(…) = _, %v, want gelf error", err)
+	}
+}
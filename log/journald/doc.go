@@ -0,0 +1,5 @@
+// Package journald contains a Logger that sends entries to the systemd journal
+// using its native protocol.
+//
+// The Logger is only available on Linux.
+package journald
@@ -0,0 +1,215 @@
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+// Config of a Logger.
+type Config struct {
+	// Address of the journal’s socket, “/run/systemd/journal/socket” if empty.
+	Address string
+
+	// Identifier is the SYSLOG_IDENTIFIER of entries made to Loggers without a
+	// name, the base name of os.Args[0] if empty.
+	Identifier string
+
+	// Priority of entries, 6, informational, if 0.
+	Priority int
+}
+
+// Logger that sends entries to the systemd journal.
+//
+// An entry is sent with the journal fields MESSAGE, the message,
+// SYSLOG_IDENTIFIER, the name of the Logger or, if it’s empty, the
+// Identifier of the Config, PRIORITY, the Priority of the Config, and a
+// journal field for each Field, beginning with those added to the Logger, then
+// the given fields.  The name of such a journal field is the label of the
+// Field in upper case, with any characters other than A–Z, 0–9, and “_”
+// replaced by “_”, prefixed by “X”, if it’d otherwise begin with “_” or a
+// digit, and truncated to 64 characters.  The value is what the Field’s
+// Value writes to a value.BytesWriter.
+//
+// If an entry is too large to be sent as a datagram, it’s written to an
+// unlinked file in /dev/shm, or os.TempDir() if that doesn’t exist, whose
+// file descriptor is then sent instead.
+//
+// A Logger is safe for concurrent use.
+type Logger struct {
+	c      *conn
+	name   string
+	fields []log.Field
+}
+
+// Dial is a new Logger that sends entries to the journal of c.
+//
+// Errors if the journal’s socket can’t be dialed.
+func Dial(c Config) (*Logger, error) {
+	if c.Address == "" {
+		c.Address = "/run/systemd/journal/socket"
+	}
+	if c.Identifier == "" && len(os.Args) > 0 {
+		c.Identifier = filepath.Base(os.Args[0])
+	}
+	if c.Priority == 0 {
+		c.Priority = 6
+	}
+	uc, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: c.Address, Net: "unixgram"})
+	if err != nil {
+		return nil, fmt.Errorf("journald: %w", err)
+	}
+	return &Logger{c: &conn{c, uc}}, nil
+}
+
+// Entry sends message and fields to the journal.
+//
+// Errors if a field errors when being written or if the entry can’t be sent.
+func (l *Logger) Entry(message string, fields ...log.Field) error {
+	identifier := l.name
+	if identifier == "" {
+		identifier = l.c.c.Identifier
+	}
+	b := make([]byte, 0, 256)
+	b = appendField(b, "MESSAGE", []byte(message))
+	b = appendField(b, "SYSLOG_IDENTIFIER", []byte(identifier))
+	b = appendField(b, "PRIORITY", strconv.AppendInt(nil, int64(l.c.c.Priority), 10))
+	for _, fs := range [][]log.Field{l.fields, fields} {
+		for _, f := range fs {
+			var w value.BytesWriter
+			if err := f.Value.Write(&w); err != nil {
+				return err
+			}
+			b = appendField(b, name(f.Label), w.Bytes)
+		}
+	}
+	return l.c.send(b)
+}
+
+// Named is a new Logger that shares l’s connection.
+func (l *Logger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &Logger{l.c, log.JoinName(l.name, name), l.fields}
+}
+
+// With is a new Logger that shares l’s connection.
+func (l *Logger) With(fields ...log.Field) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{l.c, l.name, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// Close the connection to the journal.
+func (l *Logger) Close() error {
+	return l.c.uc.Close()
+}
+
+type conn struct {
+	c  Config
+	uc *net.UnixConn
+}
+
+func (c *conn) send(b []byte) error {
+	_, err := c.uc.Write(b)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return fmt.Errorf("journald: %w", err)
+	}
+	f, err := tempFile()
+	if err != nil {
+		return fmt.Errorf("journald: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("journald: %w", err)
+	}
+	// WriteMsgUnix can’t be used on a connected datagram socket.
+	rc, err := c.uc.SyscallConn()
+	if err != nil {
+		return fmt.Errorf("journald: %w", err)
+	}
+	oob := syscall.UnixRights(int(f.Fd()))
+	if werr := rc.Write(func(fd uintptr) bool {
+		err = syscall.Sendmsg(int(fd), nil, oob, nil, 0)
+		return err != syscall.EAGAIN
+	}); werr != nil {
+		return fmt.Errorf("journald: %w", werr)
+	}
+	if err != nil {
+		return fmt.Errorf("journald: %w", err)
+	}
+	return nil
+}
+
+// tempFile is a new, unlinked file in /dev/shm or os.TempDir().
+func tempFile() (*os.File, error) {
+	dir := "/dev/shm"
+	if _, err := os.Stat(dir); err != nil {
+		dir = os.TempDir()
+	}
+	f, err := os.CreateTemp(dir, "journald-")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(f.Name()); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// appendField appends the journal field key with v to b.
+func appendField(b []byte, key string, v []byte) []byte {
+	b = append(b, key...)
+	for _, c := range v {
+		if c == '\n' {
+			b = append(b, '\n')
+			var n [8]byte
+			binary.LittleEndian.PutUint64(n[:], uint64(len(v)))
+			b = append(b, n[:]...)
+			b = append(b, v...)
+			return append(b, '\n')
+		}
+	}
+	b = append(b, '=')
+	b = append(b, v...)
+	return append(b, '\n')
+}
+
+// name is label as a journal field name, as described by Logger.
+func name(label string) string {
+	b := make([]byte, 0, len(label)+1)
+	for i := 0; i < len(label); i++ {
+		switch c := label[i]; {
+		case 'a' <= c && c <= 'z':
+			b = append(b, c-'a'+'A')
+		case 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_':
+			b = append(b, c)
+		default:
+			b = append(b, '_')
+		}
+	}
+	if len(b) == 0 || b[0] == '_' || '0' <= b[0] && b[0] <= '9' {
+		b = append([]byte{'X'}, b...)
+	}
+	if len(b) > 64 {
+		b = b[:64]
+	}
+	return string(b)
+}
@@ -0,0 +1,109 @@
+//go:build linux
+// +build linux
+
+package journald_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/journald"
+)
+
+func listen(t *testing.T) (*net.UnixConn, journald.Config) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "socket")
+	c, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c, journald.Config{Address: path, Identifier: "app"}
+}
+
+func TestLogger(t *testing.T) {
+	c, config := listen(t)
+	l, err := journald.Dial(config)
+	if err != nil {
+		t.Fatalf("journald.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], 3)
+	tests := []struct {
+		logger  log.Logger
+		message string
+		fields  []log.Field
+		want    string
+	}{
+		{l, "a", nil, "MESSAGE=a\nSYSLOG_IDENTIFIER=app\nPRIORITY=6\n"},
+		{
+			l.Named("db").With(log.Int("request-id", 1)).Named("pool"),
+			"b",
+			[]log.Field{log.String("_c", "d\ne"), log.Object("1f", log.Int("g", 2)), log.String("", "h")},
+			"MESSAGE=b\nSYSLOG_IDENTIFIER=db.pool\nPRIORITY=6\nREQUEST_ID=1\nX_C\n" + string(length[:]) + "d\ne\nX1F={g: 2}\nX=h\n",
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.logger.Entry(tt.message, tt.fields...); err != nil {
+			t.Fatalf("l.Entry(%q, …) = %v, want nil", tt.message, err)
+		}
+		b := make([]byte, 1024)
+		n, err := c.Read(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b[:n]); got != tt.want {
+			t.Errorf("l.Entry(%q, …) sent %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerLarge(t *testing.T) {
+	c, config := listen(t)
+	l, err := journald.Dial(config)
+	if err != nil {
+		t.Fatalf("journald.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	large := strings.Repeat("a", 4<<20)
+	if err := l.Entry(large); err != nil {
+		t.Fatalf("l.Entry(large) = %v, want nil", err)
+	}
+	b, oob := make([]byte, 16), make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := c.ReadMsgUnix(b, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("l.Entry(large) sent %d bytes, want 0", n)
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("syscall.ParseSocketControlMessage(…) = %v, %v, want 1 message", msgs, err)
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("syscall.ParseUnixRights(…) = %v, %v, want 1 file descriptor", fds, err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "journal")
+	defer f.Close()
+	f.Seek(0, io.SeekStart)
+	got, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "MESSAGE=" + large + "\nSYSLOG_IDENTIFIER=app\nPRIORITY=6\n"; !bytes.Equal(got, []byte(want)) {
+		t.Errorf("l.Entry(large) sent file of %d bytes, want %d", len(got), len(want))
+	}
+}
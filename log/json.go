@@ -0,0 +1,83 @@
+package log
+
+import (
+	"io"
+	"sync"
+
+	"github.com/now/x/log/value"
+)
+
+// JSON is a Logger that writes entries to w as lines of JSON.
+//
+// Each entry is written as a JSON object followed by a line feed, U+000A, in a
+// single call to w.Write.  The first member of the object is "message", the
+// message as a JSON string.  This is followed by "name", the name of the
+// Logger as a JSON string, if the name isn’t empty.  This is followed by a
+// member for each field, beginning with those added to the Logger, then the
+// given fields, as written by a value.JSONWriter.
+//
+// Nothing is written if writing a field errors.
+//
+// Fields added by WithCaller and Stack are thus written as members "caller"
+// and, conventionally, "stacktrace".
+//
+// Writes to w are serialized, so the Logger and any Logger derived from it by
+// Named or With are safe for concurrent use.
+func JSON(w io.Writer) Logger {
+	return &jsonLogger{w: &syncWriter{w: w}}
+}
+
+type jsonLogger struct {
+	w      *syncWriter
+	name   string
+	fields []Field
+}
+
+func (l *jsonLogger) Entry(message string, fields ...Field) error {
+	w := jsonWriters.Get().(*value.JSONWriter)
+	defer func() {
+		*w = value.JSONWriter{Bytes: w.Bytes[:0]}
+		jsonWriters.Put(w)
+	}()
+
+	w.Bytes = append(w.Bytes, '{')
+	w.Field("message", value.String(message).Write)
+	if l.name != "" {
+		w.Field("name", value.String(l.name).Write)
+	}
+	for _, fs := range [][]Field{l.fields, fields} {
+		for i := range fs {
+			if err := fs[i].Write(w); err != nil {
+				return err
+			}
+		}
+	}
+	w.Bytes = append(w.Bytes, '}', '\n')
+
+	_, err := l.w.Write(w.Bytes)
+	return err
+}
+
+func (l *jsonLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	c := *l
+	c.name = JoinName(l.name, name)
+	return &c
+}
+
+func (l *jsonLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	c := *l
+	c.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	return &c
+}
+
+var jsonWriters = sync.Pool{
+	New: func() interface{} {
+		return &value.JSONWriter{Bytes: make([]byte, 0, 1024)}
+	},
+}
@@ -0,0 +1,117 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("formats entries", func(t *testing.T) {
+		tests := []struct {
+			field log.Field
+			want  string
+		}{
+			{log.Bool("ok", true), `"ok":true`},
+			{log.Bytes("data", []byte("abc")), `"data":"YWJj"`},
+			{log.Duration("elapsed", 1500*time.Millisecond), `"elapsed":"1.5s"`},
+			{log.Error(fmt.Errorf("failed")), `"error":"failed"`},
+			{log.Error(fmt.Errorf("failed: %w", errors.New("cause"))), `"error":{"message":"failed: cause","type":"*fmt.wrapError","causes":[{"message":"cause","type":"*errors.errorString"}]}`},
+			{log.Float64("ratio", 0.5), `"ratio":0.5`},
+			{log.Float64("inf", math.Inf(1)), `"inf":"+Inf"`},
+			{log.Int("ID", 1), `"ID":1`},
+			{log.Int64("neg", -1), `"neg":-1`},
+			{log.Reflect("values", map[string]int{"a": 1}), `"values":{"a":1}`},
+			{log.Reflect("html", "<&>"), `"html":"<&>"`},
+			{log.String("name", "something"), `"name":"something"`},
+			{log.String("escaped", "\"\\\n\r\t\x00\u2028\xff"), `"escaped":"\"\\\n\r\t\u0000\u2028�"`},
+			{log.Stringer("string", stringer("stringed")), `"string":"stringed"`},
+			{log.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), `"at":"2022-03-09T19:51:00Z"`},
+			{log.Struct("user", struct {
+				Name  string `log:"name"`
+				Token string `log:"token,redact"`
+			}{"a", "b"}), `"user":{"name":"a","token":"[REDACTED]"}`},
+			{log.Object("object", log.Int("a", 1), log.Object("b"), log.Array("c", value.String("d"), value.Object{log.Int("e", 2)})), `"object":{"a":1,"b":{},"c":["d",{"e":2}]}`},
+			{log.Array("array", log.Int("a", 1), value.Int(2)), `"array":[{"a":1},2]`},
+			{log.Field{Label: "field", Value: log.Int("a", 1)}, `"field":{"a":1}`},
+			{log.Field{Label: "values", Value: value.Func(func(w value.Writer) error {
+				w.String("a")
+				w.Int(1)
+				return value.Object{log.Int("b", 2)}.Write(w)
+			})}, `"values":["a",1,{"b":2}]`},
+			{log.Field{Label: "nothing", Value: value.Func(func(value.Writer) error { return nil })}, `"nothing":null`},
+		}
+		for _, tt := range tests {
+			var b bytes.Buffer
+			if err := log.JSON(&b).Entry("abc", tt.field); err != nil {
+				t.Errorf("log.JSON(…).Entry(\"abc\", %#v) = %v, want nil", tt.field, err)
+			} else if got, want := b.String(), fmt.Sprintf("{\"message\":\"abc\",%s}\n", tt.want); got != want {
+				t.Errorf("log.JSON(…).Entry(\"abc\", %#v) = %#v, want %#v", tt.field, got, want)
+			} else if !json.Valid(b.Bytes()) {
+				t.Errorf("log.JSON(…).Entry(\"abc\", %#v) = %#v, want valid JSON", tt.field, got)
+			}
+		}
+	})
+
+	t.Run("names and fields from Logger and it’s parents", func(t *testing.T) {
+		var b bytes.Buffer
+		log.JSON(&b).Named("a").With(log.Int("b", 1)).Named("").Named("c").With().With(log.Int("d", 2)).Entry("e", log.Int("f", 3))
+		if got, want := b.String(), "{\"message\":\"e\",\"name\":\"a.c\",\"b\":1,\"d\":2,\"f\":3}\n"; got != want {
+			t.Errorf("log.JSON(…).Named(\"a\")….Entry(\"e\", …) = %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("siblings don’t share fields", func(t *testing.T) {
+		var b bytes.Buffer
+		l := log.JSON(&b).With(log.Int("a", 1))
+		l.With(log.Int("b", 2)).Entry("c")
+		l.With(log.Int("d", 3)).Entry("e")
+		if got, want := b.String(), "{\"message\":\"c\",\"a\":1,\"b\":2}\n{\"message\":\"e\",\"a\":1,\"d\":3}\n"; got != want {
+			t.Errorf("log.JSON(…).With(…) siblings = %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("writes nothing on errors", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := log.JSON(&b).Entry("abc", log.Stringer("s", stringerPanicker{})); err == nil {
+			t.Errorf("log.JSON(…).Entry(\"abc\", …) = nil, want error")
+		}
+		if err := log.JSON(&b).Entry("abc", log.Reflect("f", func() {})); err == nil {
+			t.Errorf("log.JSON(…).Entry(\"abc\", …) = nil, want error")
+		}
+		if b.Len() != 0 {
+			t.Errorf("log.JSON(…).Entry(\"abc\", …) wrote %#v, want nothing", b.String())
+		}
+	})
+
+	t.Run("writes lines concurrently", func(t *testing.T) {
+		var b bytes.Buffer
+		l := log.JSON(&b)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l.Named("a").Entry("b")
+			}()
+		}
+		wg.Wait()
+		if got, want := b.String(), string(bytes.Repeat([]byte("{\"message\":\"b\",\"name\":\"a\"}\n"), 10)); got != want {
+			t.Errorf("log.JSON(…).Entry(…) concurrently = %#v, want %#v", got, want)
+		}
+	})
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
@@ -0,0 +1,79 @@
+package log
+
+import (
+	"io"
+	"sync"
+
+	"github.com/now/x/log/value"
+)
+
+// Logfmt is a Logger that writes entries to w as lines of logfmt.
+//
+// Each entry is written as a line of key-value pairs followed by a line feed,
+// U+000A, in a single call to w.Write.  The first pair is "msg", the message.
+// This is followed by "logger", the name of the Logger, if the name isn’t
+// empty.  This is followed by a pair for each field, beginning with those
+// added to the Logger, then the given fields, as written by a
+// value.LogfmtWriter.  Fields with the same label are all written, in order.
+//
+// Nothing is written if writing a field errors.
+//
+// Writes to w are serialized, so the Logger and any Logger derived from it by
+// Named or With are safe for concurrent use.
+func Logfmt(w io.Writer) Logger {
+	return &logfmtLogger{w: &syncWriter{w: w}}
+}
+
+type logfmtLogger struct {
+	w      *syncWriter
+	name   string
+	fields []Field
+}
+
+func (l *logfmtLogger) Entry(message string, fields ...Field) error {
+	w := logfmtWriters.Get().(*value.LogfmtWriter)
+	defer func() {
+		*w = value.LogfmtWriter{Bytes: w.Bytes[:0]}
+		logfmtWriters.Put(w)
+	}()
+
+	w.Field("msg", value.String(message).Write)
+	if l.name != "" {
+		w.Field("logger", value.String(l.name).Write)
+	}
+	for _, fs := range [][]Field{l.fields, fields} {
+		for i := range fs {
+			if err := fs[i].Write(w); err != nil {
+				return err
+			}
+		}
+	}
+	w.Bytes = append(w.Bytes, '\n')
+
+	_, err := l.w.Write(w.Bytes)
+	return err
+}
+
+func (l *logfmtLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	c := *l
+	c.name = JoinName(l.name, name)
+	return &c
+}
+
+func (l *logfmtLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	c := *l
+	c.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	return &c
+}
+
+var logfmtWriters = sync.Pool{
+	New: func() interface{} {
+		return &value.LogfmtWriter{Bytes: make([]byte, 0, 1024)}
+	},
+}
@@ -0,0 +1,76 @@
+package log_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestLogfmt(t *testing.T) {
+	t.Run("formats entries", func(t *testing.T) {
+		tests := []struct {
+			field log.Field
+			want  string
+		}{
+			{log.Bool("ok", true), `ok=true`},
+			{log.Bytes("data", []byte("abc")), `data=YWJj`},
+			{log.Duration("elapsed", 1500*time.Millisecond), `elapsed=1.5s`},
+			{log.Error(fmt.Errorf("failed")), `error=failed`},
+			{log.Float64("ratio", 0.5), `ratio=0.5`},
+			{log.Int("ID", 1), `ID=1`},
+			{log.Int64("neg", -1), `neg=-1`},
+			{log.Reflect("values", map[string]int{"a": 1}), `values=map[a:1]`},
+			{log.String("name", "something"), `name=something`},
+			{log.String("empty", ""), `empty=""`},
+			{log.String("spaced", "a b"), `spaced="a b"`},
+			{log.String("quoted", `a="b"`), `quoted="a=\"b\""`},
+			{log.String("lines", "a\nb"), `lines="a\nb"`},
+			{log.String("a key=", "b"), `a_key_=b`},
+			{log.String("", "b"), `_=b`},
+			{log.Stringer("string", stringer("stringed")), `string=stringed`},
+			{log.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), `at=2022-03-09T19:51:00Z`},
+			{log.Object("object", log.Int("a", 1), log.Object("b", log.String("c", "d"))), `object.a=1 object.b.c=d`},
+			{log.Object("empty"), `empty=`},
+			{log.Array("array", value.Int(1), value.String("b c")), `array=1,"b c"`},
+			{log.Array("objects", value.Object{log.Int("a", 1)}, value.Object{log.Int("a", 2)}), `objects.a=1 objects.a=2`},
+			{log.Field{Label: "n", Value: log.Int("b", 1)}, `n.b=1`},
+			{log.Field{Label: "n", Value: log.Object("o", log.Field{Label: "p", Value: log.Int("b", 1)})}, `n.o.p.b=1`},
+			{log.Array("a", value.Int(2), log.Int("x", 1), value.String("x"), value.Int(3)), `a=2 a.x=1 a=x,3`},
+			{log.Field{Label: "f", Value: value.Func(func(w value.Writer) error {
+				w.Int(1)
+				w.Field("b", value.Int(2).Write)
+				return w.Int(3)
+			})}, `f=1 f.b=2 f=3`},
+			{log.String("commas", "x,y"), `commas="x,y"`},
+		}
+		for _, tt := range tests {
+			var b bytes.Buffer
+			if err := log.Logfmt(&b).Entry("a b", tt.field); err != nil {
+				t.Errorf("log.Logfmt(…).Entry(\"a b\", %#v) = %v, want nil", tt.field, err)
+			} else if got, want := b.String(), fmt.Sprintf("msg=\"a b\" %s\n", tt.want); got != want {
+				t.Errorf("log.Logfmt(…).Entry(\"a b\", %#v) = %#v, want %#v", tt.field, got, want)
+			}
+		}
+	})
+
+	t.Run("names and fields from Logger and it’s parents", func(t *testing.T) {
+		var b bytes.Buffer
+		log.Logfmt(&b).Named("db").With(log.Int("user", 42)).Named("pool").Entry("e", log.Int("user", 43))
+		if got, want := b.String(), "msg=e logger=db.pool user=42 user=43\n"; got != want {
+			t.Errorf("log.Logfmt(…).Named(\"db\")….Entry(\"e\", …) = %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("writes nothing on errors", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := log.Logfmt(&b).Entry("abc", log.Stringer("s", stringerPanicker{})); err == nil {
+			t.Errorf("log.Logfmt(…).Entry(\"abc\", …) = nil, want error")
+		} else if b.Len() != 0 {
+			t.Errorf("log.Logfmt(…).Entry(\"abc\", …) wrote %#v, want nothing", b.String())
+		}
+	})
+}
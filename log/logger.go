@@ -24,3 +24,12 @@ type Logger interface {
 	// following log entries added to the new Logger.
 	With(...Field) Logger
 }
+
+// JoinName is the name of a Logger named name whose parent is named parent, as
+// described by Logger.Named.  It’s useful for implementing Named.
+func JoinName(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
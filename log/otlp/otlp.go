@@ -0,0 +1,399 @@
+// Package otlp contains a Logger that exports entries to an OpenTelemetry
+// collector as OTLP/HTTP JSON.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+	xhttp "github.com/now/x/net/http"
+	xtime "github.com/now/x/time"
+)
+
+// Config of a Logger.
+type Config struct {
+	// URL of the logs endpoint of the collector,
+	// “http://localhost:4318/v1/logs” if empty.
+	URL string
+
+	// Header of requests, in addition to Content-Type.
+	Header http.Header
+
+	// Resource attributes of exported entries, such as “service.name”.
+	Resource []log.Field
+
+	// BatchSize is the maximum number of entries per request, 512 if ≤ 0.
+	BatchSize int
+
+	// Interval at which buffered entries are exported, even if there are fewer
+	// than BatchSize of them, one second if ≤ 0.
+	Interval time.Duration
+
+	// BufferSize is the maximum number of entries that are buffered before
+	// they’re exported, 2048 if ≤ 0.
+	BufferSize int
+
+	// Retries is the maximum number of times a request is retried, five if 0
+	// and none if < 0.
+	Retries int
+
+	// Backoff is the time to wait before the first retry, which doubles for
+	// each subsequent retry, 500 milliseconds if ≤ 0.
+	Backoff time.Duration
+
+	// Clock to timestamp entries with, time.Now if nil.
+	Clock xtime.Clock
+}
+
+// Logger that exports entries to an OpenTelemetry collector as OTLP/HTTP
+// JSON.
+//
+// An entry is buffered as a log record with the time of the entry as its time
+// and observed time, the message as its string body, and an attribute for each
+// Field, beginning with those added to the Logger, then the given fields.  A
+// Field’s Value is written as an AnyValue: value.Bool as a boolValue,
+// value.Int and value.Int64 as an intValue, value.Float64 as a doubleValue,
+// value.Bytes as a bytesValue, value.Object as a kvlistValue, value.Array as an
+// arrayValue, value.Reflect as whatever its encoding/json encoding maps to,
+// and everything else, including value.Duration and value.Time, as a
+// stringValue of how a value.JSONWriter would write it.
+//
+// Buffered records are exported in batches by a goroutine, either when
+// BatchSize records have been buffered or when Interval has passed, by POSTing
+// them to the URL with the *http.Client of the context.Context given to New.
+// Records are grouped by the name of the Logger that made them, which is used
+// as the name of their instrumentation scope, and share a resource with the
+// attributes of Resource.  A request that fails with a network error or with
+// one of the statuses 429, 502, 503, and 504 is retried with exponential
+// backoff, or after the duration of the response’s Retry-After header, if any.
+//
+// A Logger is safe for concurrent use.
+type Logger struct {
+	e      *exporter
+	name   string
+	fields []log.Field
+}
+
+// New is a new Logger that exports entries according to c, making requests
+// with ctx and the *http.Client of xhttp.In(ctx).
+//
+// The Logger must be closed with Close to stop its goroutine.
+//
+// Errors if a Field of c.Resource errors when being written.
+func New(ctx context.Context, c Config) (*Logger, error) {
+	if c.URL == "" {
+		c.URL = "http://localhost:4318/v1/logs"
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 512
+	}
+	if c.Interval <= 0 {
+		c.Interval = time.Second
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = 2048
+	}
+	if c.Retries == 0 {
+		c.Retries = 5
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = 500 * time.Millisecond
+	}
+	if c.Clock == nil {
+		c.Clock = xtime.ClockFunc(time.Now)
+	}
+	w := value.JSONWriter{}
+	if err := writeAttributes(&w, [2][]log.Field{c.Resource}); err != nil {
+		return nil, err
+	}
+	e := &exporter{
+		c:        c,
+		ctx:      ctx,
+		client:   xhttp.In(ctx),
+		resource: w.Bytes,
+		kick:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	go e.run()
+	return &Logger{e: e}, nil
+}
+
+// Entry buffers message and fields as a log record.
+//
+// Errors if a field errors when being written, with log.ErrDropped if the
+// buffer is full, and with log.ErrClosed if l has been closed.
+func (l *Logger) Entry(message string, fields ...log.Field) error {
+	w := value.JSONWriter{Bytes: make([]byte, 0, 256)}
+	now := strconv.FormatInt(l.e.c.Clock.Now().UnixNano(), 10)
+	w.Bytes = append(w.Bytes, '{')
+	w.Field("timeUnixNano", value.String(now).Write)
+	w.Field("observedTimeUnixNano", value.String(now).Write)
+	w.Field("body", anyWriter{w: &w}.value(value.String(message)))
+	if len(l.fields)+len(fields) > 0 {
+		err := w.Field("attributes", func(value.Writer) error {
+			return writeAttributes(&w, [2][]log.Field{l.fields, fields})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Bytes = append(w.Bytes, '}')
+	return l.e.push(record{l.name, w.Bytes})
+}
+
+// Named is a new Logger that shares l’s buffer.
+func (l *Logger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &Logger{l.e, log.JoinName(l.name, name), l.fields}
+}
+
+// With is a new Logger that shares l’s buffer.
+func (l *Logger) With(fields ...log.Field) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{l.e, l.name, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// Dropped is the number of entries that have been dropped due to the buffer
+// being full.
+func (l *Logger) Dropped() uint64 {
+	l.e.mu.Lock()
+	defer l.e.mu.Unlock()
+	return l.e.dropped
+}
+
+// Flush exports the buffered entries and waits until they’ve been exported.
+//
+// Errors if ctx is done first or with the first error that exporting entries
+// has resulted in since the last call to Flush or Close.
+func (l *Logger) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	l.e.mu.Lock()
+	l.e.flushes = append(l.e.flushes, flushed)
+	l.e.mu.Unlock()
+	l.e.wake()
+	select {
+	case <-flushed:
+		return l.e.takeErr()
+	case <-l.e.done:
+		return l.e.takeErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting entries and waits until the buffered entries have been
+// exported and the goroutine has stopped.
+//
+// Errors like Flush.
+func (l *Logger) Close(ctx context.Context) error {
+	l.e.mu.Lock()
+	l.e.closed = true
+	l.e.mu.Unlock()
+	l.e.wake()
+	select {
+	case <-l.e.done:
+		return l.e.takeErr()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type record struct {
+	scope string
+	json  []byte
+}
+
+type exporter struct {
+	c        Config
+	ctx      context.Context
+	client   *http.Client
+	resource []byte
+	kick     chan struct{}
+	done     chan struct{}
+
+	mu      sync.Mutex
+	records []record
+	flushes []chan struct{}
+	dropped uint64
+	closed  bool
+	err     error
+}
+
+func (e *exporter) push(r record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return log.ErrClosed
+	}
+	if len(e.records) >= e.c.BufferSize {
+		e.dropped++
+		return log.ErrDropped
+	}
+	e.records = append(e.records, r)
+	if len(e.records) >= e.c.BatchSize {
+		e.wake()
+	}
+	return nil
+}
+
+func (e *exporter) wake() {
+	select {
+	case e.kick <- struct{}{}:
+	default:
+	}
+}
+
+func (e *exporter) run() {
+	defer close(e.done)
+	t := time.NewTicker(e.c.Interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-e.kick:
+		case <-t.C:
+		}
+		for {
+			e.mu.Lock()
+			n := len(e.records)
+			if n > e.c.BatchSize {
+				n = e.c.BatchSize
+			}
+			batch := e.records[:n:n]
+			e.records = e.records[n:]
+			var flushes []chan struct{}
+			empty := len(e.records) == 0
+			if empty {
+				e.records = nil
+				flushes, e.flushes = e.flushes, nil
+			}
+			closed := e.closed
+			e.mu.Unlock()
+			if len(batch) > 0 {
+				if err := e.export(batch); err != nil {
+					e.mu.Lock()
+					if e.err == nil {
+						e.err = err
+					}
+					e.mu.Unlock()
+				}
+			}
+			for _, f := range flushes {
+				close(f)
+			}
+			if empty {
+				if closed {
+					return
+				}
+				break
+			}
+		}
+	}
+}
+
+func (e *exporter) takeErr() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	err := e.err
+	e.err = nil
+	return err
+}
+
+// export batch, retrying as described by Logger.
+func (e *exporter) export(batch []record) error {
+	body := e.request(batch)
+	backoff := e.c.Backoff
+	for retry := 0; ; retry++ {
+		wait, err := e.post(body)
+		if err == nil || wait < 0 || retry >= e.c.Retries {
+			return err
+		}
+		if wait == 0 {
+			wait = backoff
+			backoff *= 2
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-e.ctx.Done():
+			t.Stop()
+			return fmt.Errorf("otlp: %w", e.ctx.Err())
+		}
+	}
+}
+
+// post body to the URL, returning how long to wait before retrying, if the
+// request failed, which is zero, if the default backoff should be used, and
+// negative, if the request shouldn’t be retried.
+func (e *exporter) post(body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(e.ctx, http.MethodPost, e.c.URL, bytes.NewReader(body))
+	if err != nil {
+		return -1, fmt.Errorf("otlp: %w", err)
+	}
+	for k, vs := range e.c.Header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if e.ctx.Err() != nil {
+			return -1, fmt.Errorf("otlp: %w", err)
+		}
+		return 0, fmt.Errorf("otlp: %w", err)
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+	err = fmt.Errorf("otlp: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if s, perr := strconv.Atoi(resp.Header.Get("Retry-After")); perr == nil && s > 0 {
+			return time.Duration(s) * time.Second, err
+		}
+		return 0, err
+	default:
+		return -1, err
+	}
+}
+
+// request is the body of a request exporting batch.
+func (e *exporter) request(batch []record) []byte {
+	var scopes []string
+	records := make(map[string][][]byte)
+	for _, r := range batch {
+		if _, ok := records[r.scope]; !ok {
+			scopes = append(scopes, r.scope)
+		}
+		records[r.scope] = append(records[r.scope], r.json)
+	}
+	b := make([]byte, 0, 1024)
+	b = append(b, `{"resourceLogs":[{"resource":{"attributes":`...)
+	b = append(b, e.resource...)
+	b = append(b, `},"scopeLogs":[`...)
+	for i, s := range scopes {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		w := value.JSONWriter{Bytes: append(b, `{"scope":{`...)}
+		if s != "" {
+			w.Field("name", value.String(s).Write)
+		}
+		b = append(w.Bytes, `},"logRecords":[`...)
+		b = append(b, bytes.Join(records[s], []byte{','})...)
+		b = append(b, ']', '}')
+	}
+	return append(b, "]}]}"...)
+}
@@ -0,0 +1,284 @@
+package otlp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/otlp"
+	"github.com/now/x/log/value"
+	xhttp "github.com/now/x/net/http"
+	xtime "github.com/now/x/time"
+)
+
+// collector records the bodies of the requests it receives and responds with
+// the statuses of its responses, in order, or 200 once they run out.
+type collector struct {
+	mu        sync.Mutex
+	requests  []*http.Request
+	bodies    []interface{}
+	responses []int
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b, _ := io.ReadAll(r.Body)
+	var body interface{}
+	json.Unmarshal(b, &body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, r)
+	c.bodies = append(c.bodies, body)
+	if len(c.responses) > 0 {
+		status := c.responses[0]
+		c.responses = c.responses[1:]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		http.Error(w, http.StatusText(status), status)
+	}
+}
+
+func newLogger(t *testing.T, c *collector, config otlp.Config) *otlp.Logger {
+	t.Helper()
+	s := httptest.NewServer(c)
+	t.Cleanup(s.Close)
+	config.URL = s.URL + "/v1/logs"
+	if config.Interval == 0 {
+		config.Interval = time.Hour
+	}
+	config.Backoff = time.Millisecond
+	config.Clock = xtime.StoppedAt(time.Unix(1646855460, 1))
+	l, err := otlp.New(xhttp.Using(context.Background(), s.Client()), config)
+	if err != nil {
+		t.Fatalf("otlp.New(…) = _, %v, want nil", err)
+	}
+	t.Cleanup(func() { l.Close(context.Background()) })
+	return l
+}
+
+type object map[string]interface{}
+
+type array []interface{}
+
+func keyValue(key string, value object) object {
+	return object{"key": key, "value": value}
+}
+
+func record(body string, attributes ...interface{}) object {
+	r := object{
+		"timeUnixNano":         "1646855460000000001",
+		"observedTimeUnixNano": "1646855460000000001",
+		"body":                 object{"stringValue": body},
+	}
+	if len(attributes) > 0 {
+		r["attributes"] = array(attributes)
+	}
+	return r
+}
+
+func TestLogger(t *testing.T) {
+	var c collector
+	l := newLogger(t, &c, otlp.Config{
+		Header:   http.Header{"Authorization": {"Bearer a"}},
+		Resource: []log.Field{log.String("service.name", "b")},
+	})
+	l.Entry("c")
+	l.Named("d").With(log.Int("e", 1)).Entry("f",
+		log.Bool("g", true),
+		log.Float64("h", math.Inf(1)),
+		log.Bytes("i", []byte("j")),
+		log.Duration("k", time.Second),
+		log.Object("l", log.String("m", "n")),
+		log.Array("o", value.Int64(2)),
+		log.Reflect("p", struct {
+			Q float64
+			R []interface{}
+		}{0.5, []interface{}{nil}}),
+		log.Array("s", log.Int("t", 3)),
+		log.Field{Label: "u", Value: value.Func(func(w value.Writer) error {
+			w.String("v")
+			return w.Int(4)
+		})},
+	)
+	l.Named("d").Named("s").Entry("t")
+	l.Named("d").Entry("u")
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("l.Flush(…) = %v, want nil", err)
+	}
+
+	if len(c.requests) != 1 {
+		t.Fatalf("len(requests) = %d, want 1", len(c.requests))
+	}
+	r := c.requests[0]
+	if r.Method != http.MethodPost || r.URL.Path != "/v1/logs" {
+		t.Errorf("request = %s %s, want POST /v1/logs", r.Method, r.URL.Path)
+	}
+	if got, want := r.Header.Get("Content-Type"), "application/json"; got != want {
+		t.Errorf("Content-Type = %q, want %q", got, want)
+	}
+	if got, want := r.Header.Get("Authorization"), "Bearer a"; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+	want := object{"resourceLogs": array{object{
+		"resource": object{"attributes": array{keyValue("service.name", object{"stringValue": "b"})}},
+		"scopeLogs": array{
+			object{"scope": object{}, "logRecords": array{record("c")}},
+			object{"scope": object{"name": "d"}, "logRecords": array{
+				record("f",
+					keyValue("e", object{"intValue": "1"}),
+					keyValue("g", object{"boolValue": true}),
+					keyValue("h", object{"doubleValue": "Infinity"}),
+					keyValue("i", object{"bytesValue": "ag=="}),
+					keyValue("k", object{"stringValue": "1s"}),
+					keyValue("l", object{"kvlistValue": object{"values": array{
+						keyValue("m", object{"stringValue": "n"}),
+					}}}),
+					keyValue("o", object{"arrayValue": object{"values": array{
+						object{"intValue": "2"},
+					}}}),
+					keyValue("p", object{"kvlistValue": object{"values": array{
+						keyValue("Q", object{"doubleValue": 0.5}),
+						keyValue("R", object{"arrayValue": object{"values": array{object{}}}}),
+					}}}),
+					keyValue("s", object{"arrayValue": object{"values": array{
+						object{"kvlistValue": object{"values": array{
+							keyValue("t", object{"intValue": "3"}),
+						}}},
+					}}}),
+					keyValue("u", object{"arrayValue": object{"values": array{
+						object{"stringValue": "v"},
+						object{"intValue": "4"},
+					}}}),
+				),
+				record("u"),
+			}},
+			object{"scope": object{"name": "d.s"}, "logRecords": array{record("t")}},
+		},
+	}}}
+	if diff := cmp.Diff(normalize(t, want), c.bodies[0]); diff != "" {
+		t.Errorf("request body diff -want +got\n%s", diff)
+	}
+}
+
+// normalize v into what encoding/json decodes its encoding as.
+func normalize(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n interface{}
+	if err := json.Unmarshal(b, &n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func records(body interface{}) int {
+	n := 0
+	for _, s := range body.(map[string]interface{})["resourceLogs"].([]interface{})[0].(map[string]interface{})["scopeLogs"].([]interface{}) {
+		n += len(s.(map[string]interface{})["logRecords"].([]interface{}))
+	}
+	return n
+}
+
+func TestLoggerBatches(t *testing.T) {
+	var c collector
+	l := newLogger(t, &c, otlp.Config{BatchSize: 2})
+	for i := 0; i < 5; i++ {
+		l.Entry("a")
+	}
+	if err := l.Flush(context.Background()); err != nil {
+		t.Fatalf("l.Flush(…) = %v, want nil", err)
+	}
+	var got []int
+	for _, b := range c.bodies {
+		got = append(got, records(b))
+	}
+	if diff := cmp.Diff([]int{2, 2, 1}, got); diff != "" {
+		t.Errorf("records per request diff -want +got\n%s", diff)
+	}
+}
+
+func TestLoggerInterval(t *testing.T) {
+	var c collector
+	l := newLogger(t, &c, otlp.Config{Interval: time.Millisecond})
+	l.Entry("a")
+	for i := 0; i < 500; i++ {
+		c.mu.Lock()
+		n := len(c.bodies)
+		c.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("entry wasn’t exported after Interval")
+}
+
+func TestLoggerRetries(t *testing.T) {
+	tests := []struct {
+		responses []int
+		retries   int
+		requests  int
+		err       bool
+	}{
+		{[]int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, 0, 3, false},
+		{[]int{http.StatusBadGateway, http.StatusBadGateway}, 1, 2, true},
+		{[]int{http.StatusBadRequest}, 0, 1, true},
+	}
+	for _, tt := range tests {
+		c := collector{responses: tt.responses}
+		l := newLogger(t, &c, otlp.Config{Retries: tt.retries})
+		l.Entry("a")
+		err := l.Flush(context.Background())
+		if (err != nil) != tt.err || err != nil && !strings.HasPrefix(err.Error(), "otlp: ") {
+			t.Errorf("%v: l.Flush(…) = %v, want error %t", tt.responses, err, tt.err)
+		}
+		if len(c.requests) != tt.requests {
+			t.Errorf("%v: len(requests) = %d, want %d", tt.responses, len(c.requests), tt.requests)
+		}
+	}
+}
+
+func TestLoggerBuffer(t *testing.T) {
+	var c collector
+	l := newLogger(t, &c, otlp.Config{BufferSize: 2})
+	for i, want := range []error{nil, nil, log.ErrDropped} {
+		if err := l.Entry("a"); !errors.Is(err, want) {
+			t.Errorf("%d: l.Entry(…) = %v, want %v", i, err, want)
+		}
+	}
+	if got := l.Dropped(); got != 1 {
+		t.Errorf("l.Dropped() = %d, want 1", got)
+	}
+}
+
+func TestLoggerClose(t *testing.T) {
+	var c collector
+	l := newLogger(t, &c, otlp.Config{})
+	l.Entry("a")
+	if err := l.Close(context.Background()); err != nil {
+		t.Fatalf("l.Close(…) = %v, want nil", err)
+	}
+	if len(c.bodies) != 1 || records(c.bodies[0]) != 1 {
+		t.Errorf("requests = %v, want one with one record", c.bodies)
+	}
+	if err := l.Entry("b"); err != log.ErrClosed {
+		t.Errorf("l.Entry(…) after Close = %v, want %v", err, log.ErrClosed)
+	}
+	if err := l.Flush(context.Background()); err != nil {
+		t.Errorf("l.Flush(…) after Close = %v, want nil", err)
+	}
+}
@@ -0,0 +1,226 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+// writeAttributes writes fieldss to w as a JSON array of KeyValues.
+func writeAttributes(w *value.JSONWriter, fieldss [2][]log.Field) error {
+	return w.Array(func(value.Writer) error {
+		a := anyWriter{w: w, kv: true}
+		for _, fields := range fieldss {
+			for _, f := range fields {
+				if err := a.Field(f.Label, f.Value.Write); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// anyWriter writes values to w as OTLP AnyValues and Fields as KeyValues, if
+// kv is true, as described by Logger.  If kv is false, a Field is written as
+// a kvlistValue of a single KeyValue.  The AnyValues written are counted in n,
+// if it isn’t nil.
+type anyWriter struct {
+	w  *value.JSONWriter
+	kv bool
+	n  *int
+}
+
+// value is a function that writes v to a.
+func (a anyWriter) value(v log.Value) func(value.Writer) error {
+	return func(value.Writer) error {
+		return v.Write(a)
+	}
+}
+
+// any writes an AnyValue with a member labeled label written by f, or an
+// empty AnyValue, if label is empty.
+func (a anyWriter) any(label string, f func(value.Writer) error) error {
+	if a.n != nil {
+		*a.n++
+	}
+	return a.w.Object(func(value.Writer) error {
+		if label == "" {
+			return nil
+		}
+		return a.w.Field(label, f)
+	})
+}
+
+func (a anyWriter) Bool(b bool) error {
+	return a.any("boolValue", value.Bool(b).Write)
+}
+
+func (a anyWriter) Float64(f float64) error {
+	switch {
+	case math.IsNaN(f):
+		return a.any("doubleValue", value.String("NaN").Write)
+	case math.IsInf(f, 1):
+		return a.any("doubleValue", value.String("Infinity").Write)
+	case math.IsInf(f, -1):
+		return a.any("doubleValue", value.String("-Infinity").Write)
+	default:
+		return a.any("doubleValue", value.Float64(f).Write)
+	}
+}
+
+func (a anyWriter) Duration(d time.Duration) error {
+	return a.String(d.String())
+}
+
+func (a anyWriter) Time(t time.Time) error {
+	return a.String(t.Format(time.RFC3339Nano))
+}
+
+func (a anyWriter) Binary(b []byte) error {
+	return a.any("bytesValue", value.Bytes(b).Write)
+}
+
+func (a anyWriter) Int(i int) error {
+	return a.Int64(int64(i))
+}
+
+func (a anyWriter) Int64(i int64) error {
+	return a.any("intValue", value.String(strconv.FormatInt(i, 10)).Write)
+}
+
+func (a anyWriter) String(s string) error {
+	return a.any("stringValue", value.String(s).Write)
+}
+
+func (a anyWriter) Object(f func(value.Writer) error) error {
+	return a.any("kvlistValue", a.values(true, f))
+}
+
+func (a anyWriter) Array(f func(value.Writer) error) error {
+	return a.any("arrayValue", a.values(false, f))
+}
+
+// values is a function that writes an object whose “values” member is an
+// array written by f, writing KeyValues, if kv is true, and AnyValues,
+// otherwise.
+func (a anyWriter) values(kv bool, f func(value.Writer) error) func(value.Writer) error {
+	return func(value.Writer) error {
+		return a.w.Object(func(value.Writer) error {
+			return a.w.Field("values", func(value.Writer) error {
+				return a.w.Array(func(value.Writer) error {
+					return f(anyWriter{w: a.w, kv: kv})
+				})
+			})
+		})
+	}
+}
+
+// Field writes a KeyValue with label as its key and the AnyValue written by f
+// as its value, or, if a doesn’t write KeyValues, a kvlistValue of such a
+// KeyValue.
+//
+// The AnyValue is an empty AnyValue, if f writes nothing, and an arrayValue of
+// what f writes, if it writes more than one AnyValue.
+func (a anyWriter) Field(label string, f func(value.Writer) error) error {
+	if !a.kv {
+		return a.Object(func(w value.Writer) error {
+			return w.Field(label, f)
+		})
+	}
+	var (
+		n int
+		v value.JSONWriter
+	)
+	err := v.Array(func(value.Writer) error {
+		return f(anyWriter{w: &v, n: &n})
+	})
+	if err != nil {
+		return err
+	}
+	return a.w.Object(func(value.Writer) error {
+		a.w.Field("key", value.String(label).Write)
+		return a.w.Field("value", func(value.Writer) error {
+			switch n {
+			case 0:
+				a.w.Bytes = append(a.w.Bytes, '{', '}')
+			case 1:
+				a.w.Bytes = append(a.w.Bytes, v.Bytes[1:len(v.Bytes)-1]...)
+			default:
+				a.w.Bytes = append(a.w.Bytes, `{"arrayValue":{"values":`...)
+				a.w.Bytes = append(a.w.Bytes, v.Bytes...)
+				a.w.Bytes = append(a.w.Bytes, '}', '}')
+			}
+			return nil
+		})
+	})
+}
+
+// Reflect writes r as the AnyValue of its encoding/json encoding.
+//
+// Errors if r can’t be marshaled.
+func (a anyWriter) Reflect(r interface{}) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var x interface{}
+	if err := d.Decode(&x); err != nil {
+		return err
+	}
+	return a.json(x)
+}
+
+// json writes x, the result of decoding JSON, as an AnyValue.
+func (a anyWriter) json(x interface{}) error {
+	switch x := x.(type) {
+	case bool:
+		return a.Bool(x)
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return a.Int64(i)
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return a.String(x.String())
+		}
+		return a.Float64(f)
+	case string:
+		return a.String(x)
+	case []interface{}:
+		return a.Array(func(w value.Writer) error {
+			for _, e := range x {
+				if err := w.(anyWriter).json(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return a.Object(func(w value.Writer) error {
+			for _, k := range keys {
+				e := x[k]
+				if err := w.Field(k, func(w value.Writer) error { return w.(anyWriter).json(e) }); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	default:
+		// x is nil, which is written as an empty AnyValue.
+		return a.any("", nil)
+	}
+}
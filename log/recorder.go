@@ -0,0 +1,240 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+// Recorder is a Logger that records entries for later inspection.
+//
+// This is primarily useful in testing, where it allows for verifying that the
+// wanted entries were made, as Records can be compared with
+// github.com/google/go-cmp.
+//
+// Loggers derived from a Recorder by Named or With record into the Recorder.
+//
+// The zero value is ready for use and a Recorder is safe for concurrent use.
+type Recorder struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// Record of an entry made to a Recorder.
+type Record struct {
+	Name    string        // Name of the Logger that the entry was made to.
+	Message string        // Message of the entry.
+	Fields  []RecordField // Fields of the Logger, then those of the entry.
+}
+
+// RecordField is a Field as recorded by a Recorder.
+//
+// The Value is what the Field’s Value wrote: an int for value.Writer.Int, an
+// int64 for value.Writer.Int64, the value itself for value.Writer.Reflect, a
+// string for value.Writer.String, and a []RecordField for any Fields.
+// Similarly, a value.Bool, value.Bytes, value.Duration, value.Float64, and
+// value.Time is recorded as a bool, []byte, time.Duration, float64, and
+// time.Time, respectively.  A value.Object is recorded as a []RecordField of
+// its fields and a value.Array as a []interface{} of its elements, where a
+// Field is recorded as a []RecordField of only that Field.  If nothing was
+// written, Value is nil.  If more than one value was written, Value is a
+// []interface{} of the values.
+type RecordField struct {
+	Label string
+	Value interface{}
+}
+
+// Entry records message and fields.
+//
+// Errors if any field errors when being written, in which case nothing is
+// recorded.
+func (r *Recorder) Entry(message string, fields ...Field) error {
+	return r.record("", nil, message, fields)
+}
+
+// Named is a new Logger named name that records into r.
+func (r *Recorder) Named(name string) Logger {
+	if name == "" {
+		return r
+	}
+	return &recorderLogger{r: r, name: name}
+}
+
+// With is a new Logger with fields that records into r.
+func (r *Recorder) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return r
+	}
+	return &recorderLogger{r: r, fields: fields}
+}
+
+// Entries recorded so far, in order.
+func (r *Recorder) Entries() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Record(nil), r.records...)
+}
+
+// Find the entries recorded so far with message, in order.
+func (r *Recorder) Find(message string) []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var records []Record
+	for _, rec := range r.records {
+		if rec.Message == message {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// Value of the first of r.Fields labeled label.
+//
+// The boolean is false if there’s no such field.
+func (r Record) Value(label string) (interface{}, bool) {
+	for _, f := range r.Fields {
+		if f.Label == label {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Recorder) record(name string, inherited []Field, message string, fields []Field) error {
+	var w recordWriter
+	for _, fs := range [][]Field{inherited, fields} {
+		for i := range fs {
+			if err := fs[i].Write(&w); err != nil {
+				return err
+			}
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, Record{name, message, w.fields})
+	return nil
+}
+
+type recorderLogger struct {
+	r      *Recorder
+	name   string
+	fields []Field
+}
+
+func (l *recorderLogger) Entry(message string, fields ...Field) error {
+	return l.r.record(l.name, l.fields, message, fields)
+}
+
+func (l *recorderLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	c := *l
+	c.name = JoinName(l.name, name)
+	return &c
+}
+
+func (l *recorderLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	c := *l
+	c.fields = append(l.fields[:len(l.fields):len(l.fields)], fields...)
+	return &c
+}
+
+type recordWriter struct {
+	values []interface{}
+	fields []RecordField
+	array  bool // array is true if Fields are elements of a value.Array.
+}
+
+func (w *recordWriter) Bool(b bool) error {
+	w.values = append(w.values, b)
+	return nil
+}
+
+func (w *recordWriter) Float64(f float64) error {
+	w.values = append(w.values, f)
+	return nil
+}
+
+func (w *recordWriter) Duration(d time.Duration) error {
+	w.values = append(w.values, d)
+	return nil
+}
+
+func (w *recordWriter) Time(t time.Time) error {
+	w.values = append(w.values, t)
+	return nil
+}
+
+func (w *recordWriter) Binary(b []byte) error {
+	w.values = append(w.values, append([]byte(nil), b...))
+	return nil
+}
+
+func (w *recordWriter) Int(i int) error {
+	w.values = append(w.values, i)
+	return nil
+}
+
+func (w *recordWriter) Int64(i int64) error {
+	w.values = append(w.values, i)
+	return nil
+}
+
+func (w *recordWriter) Reflect(r interface{}) error {
+	w.values = append(w.values, r)
+	return nil
+}
+
+func (w *recordWriter) String(s string) error {
+	w.values = append(w.values, s)
+	return nil
+}
+
+func (w *recordWriter) Object(f func(value.Writer) error) error {
+	var ow recordWriter
+	if err := f(&ow); err != nil {
+		return err
+	}
+	w.values = append(w.values, append([]RecordField{}, ow.fields...))
+	return nil
+}
+
+func (w *recordWriter) Array(f func(value.Writer) error) error {
+	aw := recordWriter{array: true}
+	if err := f(&aw); err != nil {
+		return err
+	}
+	w.values = append(w.values, append([]interface{}{}, aw.values...))
+	return nil
+}
+
+func (w *recordWriter) Field(label string, f func(value.Writer) error) error {
+	var fw recordWriter
+	if err := f(&fw); err != nil {
+		return err
+	}
+	if w.array {
+		w.values = append(w.values, []RecordField{{label, fw.value()}})
+	} else {
+		w.fields = append(w.fields, RecordField{label, fw.value()})
+	}
+	return nil
+}
+
+func (w *recordWriter) value() interface{} {
+	switch {
+	case len(w.fields) > 0:
+		return w.fields
+	case len(w.values) == 0:
+		return nil
+	case len(w.values) == 1:
+		return w.values[0]
+	default:
+		return w.values
+	}
+}
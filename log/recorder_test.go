@@ -0,0 +1,128 @@
+package log_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Run("records entries", func(t *testing.T) {
+		var r log.Recorder
+		ctx := log.Using(context.Background(), &r)
+		log.Entry(ctx, "a")
+		log.Entry(log.With(log.Named(ctx, "b"), log.Int("c", 1)), "retrying", log.Int64("attempt", 3))
+		log.Entry(log.Named(log.Named(ctx, "b"), "d"), "e", log.Error(fmt.Errorf("failed")), log.Reflect("f", []int{1}))
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a"},
+			{Name: "b", Message: "retrying", Fields: []log.RecordField{{"c", 1}, {"attempt", int64(3)}}},
+			{Name: "b.d", Message: "e", Fields: []log.RecordField{{"error", "failed"}, {"f", []int{1}}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("records typed values", func(t *testing.T) {
+		var r log.Recorder
+		at := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+		r.Entry("a", log.Bool("b", true), log.Bytes("c", []byte("d")), log.Duration("e", time.Second), log.Float64("f", 0.5), log.Time("g", at))
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{{"b", true}, {"c", []byte("d")}, {"e", time.Second}, {"f", 0.5}, {"g", at}}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("records nested fields and multiple values", func(t *testing.T) {
+		var r log.Recorder
+		r.Entry("a", log.Field{Label: "b", Value: nested{log.String("c", "d")}}, log.Field{Label: "e", Value: multiple{}})
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{
+				{"b", []log.RecordField{{"c", "d"}}},
+				{"e", []interface{}{1, "f"}},
+			}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("records objects and arrays", func(t *testing.T) {
+		var r log.Recorder
+		r.Entry("a", log.Object("b", log.Int("c", 1)), log.Array("d", value.Int(2), value.Object{log.String("e", "f")}), log.Object("g"), log.Array("h", log.Int("i", 3)))
+		if diff := cmp.Diff(r.Entries(), []log.Record{
+			{Message: "a", Fields: []log.RecordField{
+				{"b", []log.RecordField{{"c", 1}}},
+				{"d", []interface{}{2, []log.RecordField{{"e", "f"}}}},
+				{"g", []log.RecordField{}},
+				{"h", []interface{}{[]log.RecordField{{"i", 3}}}},
+			}},
+		}); diff != "" {
+			t.Errorf("r.Entries() diff -got +want\n%s", diff)
+		}
+	})
+
+	t.Run("records nothing on errors", func(t *testing.T) {
+		var r log.Recorder
+		if err := r.With(log.Stringer("s", stringerPanicker{})).Entry("abc"); err == nil {
+			t.Errorf("r.With(…).Entry(\"abc\") = nil, want error")
+		} else if got := r.Entries(); len(got) != 0 {
+			t.Errorf("r.Entries() = %#v, want none", got)
+		}
+	})
+
+	t.Run("finds entries", func(t *testing.T) {
+		var r log.Recorder
+		r.Entry("a", log.Int("i", 1))
+		r.Entry("b")
+		r.Named("c").Entry("a", log.Int("i", 2))
+		got := r.Find("a")
+		if diff := cmp.Diff(got, []log.Record{
+			{Message: "a", Fields: []log.RecordField{{"i", 1}}},
+			{Name: "c", Message: "a", Fields: []log.RecordField{{"i", 2}}},
+		}); diff != "" {
+			t.Errorf("r.Find(\"a\") diff -got +want\n%s", diff)
+		}
+		if v, ok := got[1].Value("i"); !ok || v != 2 {
+			t.Errorf("r.Find(\"a\")[1].Value(\"i\") = %#v, %v, want 2, true", v, ok)
+		}
+		if v, ok := got[1].Value("j"); ok {
+			t.Errorf("r.Find(\"a\")[1].Value(\"j\") = %#v, %v, want nil, false", v, ok)
+		}
+	})
+
+	t.Run("records concurrently", func(t *testing.T) {
+		var r log.Recorder
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.Named("a").Entry("b")
+			}()
+		}
+		wg.Wait()
+		if got := len(r.Find("b")); got != 10 {
+			t.Errorf("len(r.Find(\"b\")) = %d, want 10", got)
+		}
+	})
+}
+
+type nested struct {
+	log.Field
+}
+
+type multiple struct{}
+
+func (multiple) Write(w value.Writer) error {
+	if err := w.Int(1); err != nil {
+		return err
+	}
+	return w.String("f")
+}
@@ -0,0 +1,289 @@
+package log
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+// Redaction rule of a Logger created by Redact.
+type Redaction struct {
+	// Label pattern of Fields whose values are redacted in full, matched like
+	// the patterns of Rules, but ignoring case.  Ignored if empty.
+	Label string
+
+	// Value of strings whose matching parts are redacted.  Ignored if nil.
+	Value *regexp.Regexp
+
+	// Hash redacted values by replacing them with “sha256:” followed by the
+	// first 16 hexadecimal digits of the SHA-256 of the value, which allows for
+	// correlating entries without revealing the value.  Otherwise, redacted
+	// values are replaced by value.RedactedPlaceholder.
+	Hash bool
+}
+
+// Redact is a Logger that redacts the Fields of entries made to l, as well as
+// those added with With, according to redactions.
+//
+// The value of a Field whose label matches the Label of a Redaction is
+// redacted in full.  Otherwise, the parts of any strings that the value
+// writes that match the Value of a Redaction are redacted.  The Fields of
+// values, such as a value.Object or value.Struct, are redacted in turn.
+//
+// A value.Reflect is marshaled by encoding/json and any object members and
+// strings of the result are redacted as above.  If anything was redacted, the
+// value is replaced by a value.Reflect of the redacted result.  Values that
+// can’t be marshaled are left as is.
+//
+// The first Redaction that matches is used.
+func Redact(l Logger, redactions ...Redaction) Logger {
+	r := &redactor{make([]Redaction, len(redactions))}
+	for i, red := range redactions {
+		red.Label = strings.ToLower(red.Label)
+		r.redactions[i] = red
+	}
+	return redactLogger{l, r}
+}
+
+type redactLogger struct {
+	logger Logger
+	r      *redactor
+}
+
+func (l redactLogger) Entry(message string, fields ...Field) error {
+	return l.logger.Entry(message, l.r.fields(fields)...)
+}
+
+func (l redactLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	return redactLogger{l.logger.Named(name), l.r}
+}
+
+func (l redactLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return redactLogger{l.logger.With(l.r.fields(fields)...), l.r}
+}
+
+type redactor struct {
+	redactions []Redaction
+}
+
+func (r *redactor) fields(fields []Field) []Field {
+	redacted := make([]Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = r.field(f)
+	}
+	return redacted
+}
+
+func (r *redactor) field(f Field) Field {
+	if red, ok := r.label(f.Label); ok {
+		if !red.Hash {
+			return Field{f.Label, value.Redacted{}}
+		}
+		return Field{f.Label, value.Func(func(w value.Writer) error {
+			var bw value.BytesWriter
+			if err := f.Value.Write(&bw); err != nil {
+				return err
+			}
+			return w.String(hash(string(bw.Bytes)))
+		})}
+	}
+	return Field{f.Label, r.value(f.Value)}
+}
+
+// label is the first Redaction whose Label matches label.
+func (r *redactor) label(label string) (Redaction, bool) {
+	label = strings.ToLower(label)
+	for _, red := range r.redactions {
+		if red.Label != "" && match(red.Label, label) {
+			return red, true
+		}
+	}
+	return Redaction{}, false
+}
+
+// value is v with any Fields and strings redacted.  Values that can’t contain
+// either are returned as is, while values that aren’t known to this package
+// are wrapped, so that they’re redacted as they’re written.
+func (r *redactor) value(v Value) Value {
+	switch v := v.(type) {
+	case Field:
+		return r.field(v)
+	case value.String:
+		return value.String(r.string(string(v)))
+	case value.Object:
+		o := make(value.Object, len(v))
+		for i, e := range v {
+			o[i] = r.value(e)
+		}
+		return o
+	case value.Array:
+		a := make(value.Array, len(v))
+		for i, e := range v {
+			a[i] = r.value(e)
+		}
+		return a
+	case value.Reflect:
+		return r.reflect(v)
+	case value.Bool, value.Bytes, value.Duration, value.Float64, value.Int, value.Int64, value.Redacted, value.Time:
+		return v
+	default:
+		return value.Func(func(w value.Writer) error {
+			return v.Write(redactWriter{w, r})
+		})
+	}
+}
+
+func (r *redactor) string(s string) string {
+	for _, red := range r.redactions {
+		if red.Value != nil {
+			s = red.Value.ReplaceAllStringFunc(s, func(m string) string {
+				return red.replacement(m)
+			})
+		}
+	}
+	return s
+}
+
+func (r *redactor) reflect(v value.Reflect) Value {
+	b, err := json.Marshal(v.Value)
+	if err != nil {
+		return v
+	}
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var x interface{}
+	if err := d.Decode(&x); err != nil {
+		return v
+	}
+	if x, changed := r.walk(x); changed {
+		return value.Reflect{Value: x}
+	}
+	return v
+}
+
+// walk x, the result of decoding JSON, redacting its object members and
+// strings, reporting whether anything was redacted.
+func (r *redactor) walk(x interface{}) (interface{}, bool) {
+	changed := false
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			if red, ok := r.label(k); ok {
+				x[k] = red.replacement(jsonText(e))
+				changed = true
+			} else if e, ok := r.walk(e); ok {
+				x[k] = e
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, e := range x {
+			if e, ok := r.walk(e); ok {
+				x[i] = e
+				changed = true
+			}
+		}
+	case string:
+		if s := r.string(x); s != x {
+			return s, true
+		}
+	}
+	return x, changed
+}
+
+// jsonText is x as a string, if it is one, and as JSON, otherwise.
+func jsonText(x interface{}) string {
+	if s, ok := x.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(x)
+	return string(b)
+}
+
+func (red Redaction) replacement(s string) string {
+	if red.Hash {
+		return hash(s)
+	}
+	return value.RedactedPlaceholder
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:8])
+}
+
+// redactWriter redacts what’s written to it before writing it to w.
+type redactWriter struct {
+	w value.Writer
+	r *redactor
+}
+
+func (w redactWriter) Bool(b bool) error {
+	return value.Bool(b).Write(w.w)
+}
+
+func (w redactWriter) Float64(f float64) error {
+	return value.Float64(f).Write(w.w)
+}
+
+func (w redactWriter) Duration(d time.Duration) error {
+	return value.Duration(d).Write(w.w)
+}
+
+func (w redactWriter) Time(t time.Time) error {
+	return value.Time{Value: t}.Write(w.w)
+}
+
+func (w redactWriter) Binary(b []byte) error {
+	return value.Bytes(b).Write(w.w)
+}
+
+func (w redactWriter) Int(i int) error {
+	return w.w.Int(i)
+}
+
+func (w redactWriter) Int64(i int64) error {
+	return w.w.Int64(i)
+}
+
+func (w redactWriter) Reflect(x interface{}) error {
+	return w.r.reflect(value.Reflect{Value: x}).Write(w.w)
+}
+
+func (w redactWriter) String(s string) error {
+	return w.w.String(w.r.string(s))
+}
+
+func (w redactWriter) Object(f func(value.Writer) error) error {
+	return value.Object{w.wrap(f)}.Write(w.w)
+}
+
+func (w redactWriter) Array(f func(value.Writer) error) error {
+	return value.Array{w.wrap(f)}.Write(w.w)
+}
+
+func (w redactWriter) Field(label string, f func(value.Writer) error) error {
+	if _, ok := w.r.label(label); ok {
+		return w.r.field(Field{label, value.Func(f)}).Write(w.w)
+	}
+	return w.w.Field(label, w.wrap(f))
+}
+
+// wrap f so that it writes to a redactWriter.
+func (w redactWriter) wrap(f func(value.Writer) error) value.Func {
+	return func(ww value.Writer) error {
+		return f(redactWriter{ww, w.r})
+	}
+}
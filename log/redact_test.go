@@ -0,0 +1,61 @@
+package log_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestRedact(t *testing.T) {
+	var r log.Recorder
+	ctx := log.Using(context.Background(), log.Redact(&r,
+		log.Redaction{Label: "password"},
+		log.Redaction{Label: "*token"},
+		log.Redaction{Label: "user", Hash: true},
+		log.Redaction{Value: regexp.MustCompile(`\b\d{4}(?: ?\d{4}){3}\b`)},
+		log.Redaction{Value: regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]+`), Hash: true},
+	))
+	ctx = log.With(ctx, log.String("Password", "hunter2"))
+	log.Entry(ctx, "a",
+		log.String("access_token", "b"),
+		log.String("note", "card 4111 1111 1111 1111 used"),
+		log.String("jwt", "Bearer eyJa.b.c"),
+		log.Int("count", 1),
+		log.String("user", "alice"),
+		log.Object("request", log.Object("headers", log.String("Refresh-Token", "c"), log.String("Accept", "*/*"))),
+		log.Array("cards", value.String("4111111111111111"), value.Int(2)),
+		log.Reflect("config", map[string]interface{}{"password": "d", "hosts": []string{"e", "4111111111111111"}, "port": 80}),
+		log.Reflect("unchanged", []int{1}),
+		log.Struct("login", struct {
+			User     string `log:"user"`
+			Password string
+			Note     string
+		}{"alice", "f", "4111111111111111"}),
+		log.Error(errors.New("failed with token eyJa.b.c")),
+	)
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Message: "a", Fields: []log.RecordField{
+			{"Password", value.RedactedPlaceholder},
+			{"access_token", value.RedactedPlaceholder},
+			{"note", "card [REDACTED] used"},
+			{"jwt", "Bearer sha256:74507380c78b7b9c"},
+			{"count", 1},
+			{"user", "sha256:2bd806c97f0e00af"},
+			{"request", []log.RecordField{{"headers", []log.RecordField{{"Refresh-Token", value.RedactedPlaceholder}, {"Accept", "*/*"}}}}},
+			{"cards", []interface{}{value.RedactedPlaceholder, 2}},
+			{"config", map[string]interface{}{"password": value.RedactedPlaceholder, "hosts": []interface{}{"e", value.RedactedPlaceholder}, "port": json.Number("80")}},
+			{"unchanged", []int{1}},
+			{"login", []log.RecordField{{"user", "sha256:2bd806c97f0e00af"}, {"Password", value.RedactedPlaceholder}, {"Note", value.RedactedPlaceholder}}},
+			{"error", "failed with token sha256:74507380c78b7b9c"},
+		}},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
@@ -0,0 +1,359 @@
+// Package rotate contains an io.WriteCloser that writes to a file that’s
+// rotated based on its size and on time, which is suitable for use with the
+// Loggers of package log that write to an io.Writer.
+package rotate
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	xtime "github.com/now/x/time"
+)
+
+// Config of a File.
+type Config struct {
+	// Path of the file to write to.
+	Path string
+
+	// MaxSize in bytes that the file may grow to before it’s rotated.  The file
+	// isn’t rotated based on its size if MaxSize ≤ 0.
+	MaxSize int64
+
+	// Interval at which the file is rotated, aligned to multiples of Interval
+	// since the zero time.Time, as measured by Clock.  The file isn’t rotated
+	// based on time if Interval ≤ 0.
+	Interval time.Duration
+
+	// Backups is the number of rotated files to keep.  All rotated files are
+	// kept if Backups ≤ 0.
+	Backups int
+
+	// Compress rotated files with gzip.
+	Compress bool
+
+	// ReopenOnSIGHUP makes the File reopen Path when the process receives a
+	// SIGHUP, which lets external tools such as logrotate move the file.
+	ReopenOnSIGHUP bool
+
+	// Clock to measure time with, time.Now if nil.
+	Clock xtime.Clock
+}
+
+// File that’s rotated as described by its Config.
+//
+// The file is rotated by renaming it to its Path followed by a period and the
+// time of the rotation in the layout BackupLayout, followed by “.gz”, if it’s
+// compressed, after which a new file is created at its Path.  Then, the oldest
+// rotated files beyond the number of Backups are removed.
+//
+// A File is safe for concurrent use.
+type File struct {
+	c      Config
+	mu     sync.Mutex
+	f      *os.File // f is nil if closed or if reopening it failed.
+	closed bool
+	size   int64
+	next   time.Time
+	hup    chan os.Signal
+	done   chan struct{}
+}
+
+// BackupLayout is the layout of the time in the names of rotated files.
+const BackupLayout = "20060102T150405.000000000"
+
+// Open is a new File writing to c.Path, which is created, if it doesn’t exist,
+// and appended to, otherwise.
+//
+// Errors if the file can’t be opened.
+func Open(c Config) (*File, error) {
+	if c.Clock == nil {
+		c.Clock = xtime.ClockFunc(time.Now)
+	}
+	f := &File{c: c}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	if c.ReopenOnSIGHUP {
+		f.hup = make(chan os.Signal, 1)
+		f.done = make(chan struct{})
+		signal.Notify(f.hup, syscall.SIGHUP)
+		go f.reopenOnSIGHUP()
+	}
+	return f, nil
+}
+
+// Write b to the file, rotating it first, if writing b would make it grow
+// beyond MaxSize or if the current Interval has passed.  If the file couldn’t
+// be reopened during an earlier rotation, reopening it is tried first.
+//
+// Errors if the file can’t be reopened, rotated, or written to.
+func (f *File) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.ensureOpen(); err != nil {
+		return 0, err
+	}
+	if f.due(int64(len(b))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.f.Write(b)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate the file now, reopening it first, like Write.
+//
+// Errors if the file can’t be reopened or rotated.
+func (f *File) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.ensureOpen(); err != nil {
+		return err
+	}
+	return f.rotate()
+}
+
+// Reopen the file at its Path, which creates it, if it’s been moved or if it
+// couldn’t be reopened during a rotation.
+//
+// Errors if the file can’t be reopened.
+func (f *File) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	var err error
+	if f.f != nil {
+		err = f.f.Close()
+		f.f = nil
+	}
+	if oerr := f.open(); oerr != nil {
+		return oerr
+	}
+	return err
+}
+
+// ensureOpen reopens the file, if it couldn’t be reopened during a rotation.
+//
+// Errors with os.ErrClosed if f has been closed and if the file can’t be
+// reopened.
+func (f *File) ensureOpen() error {
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.f == nil {
+		return f.open()
+	}
+	return nil
+}
+
+// Close the file and stop reopening it on SIGHUP, even if it couldn’t be
+// reopened during a rotation.
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	f.closed = true
+	if f.hup != nil {
+		signal.Stop(f.hup)
+		close(f.done)
+	}
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+func (f *File) reopenOnSIGHUP() {
+	for {
+		select {
+		case <-f.hup:
+			f.Reopen()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+func (f *File) open() error {
+	file, err := os.OpenFile(f.c.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.f = file
+	f.size = fi.Size()
+	if f.c.Interval > 0 {
+		f.next = f.c.Clock.Now().Truncate(f.c.Interval).Add(f.c.Interval)
+	}
+	return nil
+}
+
+// due is true if the file should be rotated before writing n bytes.
+func (f *File) due(n int64) bool {
+	if f.c.MaxSize > 0 && f.size > 0 && f.size+n > f.c.MaxSize {
+		return true
+	}
+	return f.c.Interval > 0 && !f.c.Clock.Now().Before(f.next)
+}
+
+func (f *File) rotate() error {
+	if err := f.f.Close(); err != nil {
+		return err
+	}
+	backup, err := f.backupPath()
+	if err == nil {
+		err = os.Rename(f.c.Path, backup)
+	}
+	if err != nil {
+		// Keep writing to the file, as it couldn’t be rotated.
+		if oerr := f.open(); oerr != nil {
+			f.f = nil
+		}
+		return err
+	}
+	if err := f.open(); err != nil {
+		f.f = nil
+		return err
+	}
+	if f.c.Compress {
+		if err := compress(backup); err != nil {
+			return err
+		}
+	}
+	return f.prune()
+}
+
+// backupPath is a path to rename the file to that doesn’t exist.
+func (f *File) backupPath() (string, error) {
+	path := f.c.Path + "." + f.c.Clock.Now().UTC().Format(BackupLayout)
+	for i := 1; ; i++ {
+		candidate := path
+		if i > 1 {
+			candidate += "-" + strconv.Itoa(i)
+		}
+		_, err := os.Stat(candidate)
+		if os.IsNotExist(err) {
+			_, err = os.Stat(candidate + ".gz")
+		}
+		if os.IsNotExist(err) {
+			return candidate, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
+
+// prune removes the oldest rotated files beyond the number of Backups.
+func (f *File) prune() error {
+	if f.c.Backups <= 0 {
+		return nil
+	}
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+	for len(backups) > f.c.Backups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// backups are the paths of the rotated files, oldest first.
+func (f *File) backups() ([]string, error) {
+	dir, base := filepath.Split(f.c.Path)
+	if dir == "" {
+		dir = "."
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type backup struct {
+		path string
+		at   time.Time
+		i    int
+	}
+	var backups []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, base+".") {
+			continue
+		}
+		s := strings.TrimSuffix(name[len(base)+1:], ".gz")
+		i := 1
+		if j := strings.LastIndexByte(s, '-'); j >= 0 {
+			n, err := strconv.Atoi(s[j+1:])
+			if err != nil {
+				continue
+			}
+			s, i = s[:j], n
+		}
+		at, err := time.Parse(BackupLayout, s)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{filepath.Join(dir, name), at, i})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].at.Equal(backups[j].at) {
+			return backups[i].at.Before(backups[j].at)
+		}
+		return backups[i].i < backups[j].i
+	})
+	paths := make([]string, len(backups))
+	for i, b := range backups {
+		paths[i] = b.path
+	}
+	return paths, nil
+}
+
+// compress path into path.gz and removes path.
+func compress(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(path+".gz", os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		in.Close()
+		return err
+	}
+	w := gzip.NewWriter(out)
+	_, err = io.Copy(w, in)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	in.Close()
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
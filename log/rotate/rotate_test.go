@@ -0,0 +1,206 @@
+package rotate_test
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log/rotate"
+	xtime "github.com/now/x/time"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newClock() *clock {
+	return &clock{time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)}
+}
+
+var _ xtime.Clock = &clock{}
+
+// files in dir mapped to their, possibly decompressed, contents.
+func files(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := make(map[string]string)
+	for _, e := range entries {
+		f, err := os.Open(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r io.Reader = f
+		if filepath.Ext(e.Name()) == ".gz" {
+			if r, err = gzip.NewReader(f); err != nil {
+				t.Fatal(err)
+			}
+		}
+		b, err := io.ReadAll(r)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m[e.Name()] = string(b)
+	}
+	return m
+}
+
+func write(t *testing.T, f *rotate.File, s string) {
+	t.Helper()
+	if n, err := f.Write([]byte(s)); err != nil || n != len(s) {
+		t.Fatalf("f.Write(%q) = %d, %v, want %d, nil", s, n, err, len(s))
+	}
+}
+
+func TestFileMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	c := newClock()
+	f, err := rotate.Open(rotate.Config{Path: filepath.Join(dir, "log"), MaxSize: 4, Backups: 2, Clock: c})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	defer f.Close()
+	write(t, f, "ab\n")
+	write(t, f, "c\n")
+	c.now = c.now.Add(time.Second)
+	write(t, f, "d\n")
+	write(t, f, "toolong\n")
+	write(t, f, "e\n")
+	if diff := cmp.Diff(files(t, dir), map[string]string{
+		"log":                             "e\n",
+		"log.20220309T195101.000000000":   "c\nd\n",
+		"log.20220309T195101.000000000-2": "toolong\n",
+	}); diff != "" {
+		t.Errorf("files diff -got +want\n%s", diff)
+	}
+}
+
+func TestFileInterval(t *testing.T) {
+	dir := t.TempDir()
+	c := newClock()
+	c.now = c.now.Add(30 * time.Minute)
+	f, err := rotate.Open(rotate.Config{Path: filepath.Join(dir, "log"), Interval: time.Hour, Compress: true, Clock: c})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	defer f.Close()
+	write(t, f, "a\n")
+	c.now = c.now.Add(9 * time.Minute)
+	write(t, f, "b\n")
+	c.now = c.now.Add(time.Minute)
+	write(t, f, "c\n")
+	c.now = c.now.Add(59 * time.Minute)
+	write(t, f, "d\n")
+	c.now = c.now.Add(time.Minute)
+	write(t, f, "e\n")
+	if diff := cmp.Diff(files(t, dir), map[string]string{
+		"log":                              "d\ne\n",
+		"log.20220309T213000.000000000.gz": "a\nb\nc\n",
+	}); diff != "" {
+		t.Errorf("files diff -got +want\n%s", diff)
+	}
+}
+
+func TestFileAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(path, []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := rotate.Open(rotate.Config{Path: path, MaxSize: 4, Clock: newClock()})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	defer f.Close()
+	write(t, f, "b\n")
+	write(t, f, "c\n")
+	got := files(t, filepath.Dir(path))
+	if got["log"] != "c\n" || got["log.20220309T195100.000000000"] != "a\nb\n" {
+		t.Errorf("files = %v, want existing file appended to before rotation", got)
+	}
+}
+
+func TestFileClose(t *testing.T) {
+	f, err := rotate.Open(rotate.Config{Path: filepath.Join(t.TempDir(), "log")})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("f.Close() = %v, want nil", err)
+	}
+	if _, err := f.Write([]byte("a")); err != os.ErrClosed {
+		t.Errorf("f.Write(…) after Close = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+func TestFileCloseAfterFailedRotation(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "d")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := rotate.Open(rotate.Config{Path: filepath.Join(dir, "log"), ReopenOnSIGHUP: true})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		f.Close()
+		t.Skipf("can’t remove directory of open file: %v", err)
+	}
+	if err := f.Rotate(); err == nil {
+		t.Fatal("f.Rotate() = nil, want error")
+	}
+	if _, err := f.Write([]byte("a")); err == nil {
+		t.Error("f.Write(…) after failed rotation = nil, want error")
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("f.Close() = %v, want nil", err)
+	}
+	if err := f.Close(); err != os.ErrClosed {
+		t.Errorf("f.Close() after Close = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+func TestFileRecoversAfterFailedRotation(t *testing.T) {
+	for _, how := range []string{"Write", "Reopen"} {
+		t.Run(how, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "d")
+			if err := os.Mkdir(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			f, err := rotate.Open(rotate.Config{Path: filepath.Join(dir, "log")})
+			if err != nil {
+				t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+			}
+			defer f.Close()
+			if err := os.RemoveAll(dir); err != nil {
+				t.Skipf("can’t remove directory of open file: %v", err)
+			}
+			if err := f.Rotate(); err == nil {
+				t.Fatal("f.Rotate() = nil, want error")
+			}
+			if err := os.Mkdir(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if how == "Reopen" {
+				if err := f.Reopen(); err != nil {
+					t.Fatalf("f.Reopen() = %v, want nil", err)
+				}
+			}
+			write(t, f, "a\n")
+			if diff := cmp.Diff(files(t, dir), map[string]string{"log": "a\n"}); diff != "" {
+				t.Errorf("files diff -got +want\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package rotate_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log/rotate"
+)
+
+func TestFileReopenOnSIGHUP(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log")
+	f, err := rotate.Open(rotate.Config{Path: path, ReopenOnSIGHUP: true})
+	if err != nil {
+		t.Fatalf("rotate.Open(…) = _, %v, want nil", err)
+	}
+	defer f.Close()
+	write(t, f, "a\n")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Skipf("can’t send SIGHUP: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	write(t, f, "b\n")
+	if diff := cmp.Diff(files(t, dir), map[string]string{"log": "b\n", "log.1": "a\n"}); diff != "" {
+		t.Errorf("files diff -got +want\n%s", diff)
+	}
+}
@@ -0,0 +1,185 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Rules of which Loggers are enabled, based on their names.
+//
+// Rules are written as a comma-separated list of glob patterns, each optionally
+// prefixed by a hyphen-minus, U+002D, such as “-db.pool.*,db.pool.conns”.  A
+// pattern matches a name if it’s equal to the name, except that an asterisk,
+// U+002A, in the pattern matches any, possibly empty, sequence of characters,
+// including periods.  A Logger is enabled according to the last pattern that
+// matches its name: disabled, if the pattern is prefixed by a hyphen-minus,
+// enabled, otherwise.  A Logger is enabled if no pattern matches its name.
+// Spaces around patterns are ignored.
+//
+// The zero value enables all Loggers and Rules are safe for concurrent use, so
+// they can be updated at runtime with Set, for example by the http.Handler of
+// package rules.
+type Rules struct {
+	version uint64 // version is accessed atomically, so it must come first.
+	mu      sync.RWMutex
+	rules   []rule
+}
+
+type rule struct {
+	pattern string
+	enabled bool
+}
+
+// Set r to the rules of s.
+//
+// Errors if any of the patterns of s is empty, in which case r is left
+// unchanged.
+func (r *Rules) Set(s string) error {
+	var rules []rule
+	if strings.TrimSpace(s) != "" {
+		for _, p := range strings.Split(s, ",") {
+			p = strings.TrimSpace(p)
+			enabled := !strings.HasPrefix(p, "-")
+			if !enabled {
+				p = strings.TrimSpace(p[1:])
+			}
+			if p == "" {
+				return fmt.Errorf("log: empty pattern in rules %q", s)
+			}
+			rules = append(rules, rule{p, enabled})
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rules = rules
+	atomic.AddUint64(&r.version, 1)
+	return nil
+}
+
+// SetFromEnv sets r to the rules of the environment variable key, if it’s set.
+//
+// Errors like Set.
+func (r *Rules) SetFromEnv(key string) error {
+	if s, ok := os.LookupEnv(key); ok {
+		return r.Set(s)
+	}
+	return nil
+}
+
+// String is r written as described by Rules.
+func (r *Rules) String() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s := make([]string, len(r.rules))
+	for i, rl := range r.rules {
+		if rl.enabled {
+			s[i] = rl.pattern
+		} else {
+			s[i] = "-" + rl.pattern
+		}
+	}
+	return strings.Join(s, ",")
+}
+
+// Enabled is true if a Logger named name is enabled by r.
+func (r *Rules) Enabled(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i := len(r.rules) - 1; i >= 0; i-- {
+		if match(r.rules[i].pattern, name) {
+			return r.rules[i].enabled
+		}
+	}
+	return true
+}
+
+// match is true if name matches the glob pattern, as described by Rules.
+//
+// On a mismatch, only the most recent asterisk is backtracked to, letting it
+// match one more character of name, which suffices, as anything an earlier
+// asterisk could match instead can be matched by the most recent one.  This
+// bounds the time taken to O(len(pattern)·len(name)).
+func match(pattern, name string) bool {
+	p, n := 0, 0
+	star, next := -1, 0
+	for n < len(name) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, n
+			p++
+		case p < len(pattern) && pattern[p] == name[n]:
+			p++
+			n++
+		case star >= 0:
+			next++
+			p, n = star+1, next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// Ruled is a Logger that only makes entries to l if it’s enabled by rules.
+//
+// Whether the Logger is enabled is determined from its name, and that of any
+// Logger derived from it by Named, the first time an entry is made after
+// rules have been Set, so entries made to a disabled Logger cost next to
+// nothing.
+func Ruled(l Logger, rules *Rules) Logger {
+	return &ruledLogger{logger: l, rules: rules}
+}
+
+type ruledLogger struct {
+	// state is accessed atomically, so it must come first.  It’s the version of
+	// rules that enabled was determined from shifted left by one, with enabled
+	// in the least significant bit.
+	state  uint64
+	logger Logger
+	rules  *Rules
+	name   string
+}
+
+func (r *ruledLogger) Entry(message string, fields ...Field) error {
+	if !r.enabled() {
+		return nil
+	}
+	return r.logger.Entry(message, fields...)
+}
+
+func (r *ruledLogger) enabled() bool {
+	version := atomic.LoadUint64(&r.rules.version)
+	state := atomic.LoadUint64(&r.state)
+	if state>>1 == version && state != 0 {
+		return state&1 == 1
+	}
+	enabled := r.rules.Enabled(r.name)
+	state = version << 1
+	if enabled {
+		state |= 1
+	}
+	if state != 0 {
+		atomic.StoreUint64(&r.state, state)
+	}
+	return enabled
+}
+
+func (r *ruledLogger) Named(name string) Logger {
+	if name == "" {
+		return r
+	}
+	return &ruledLogger{logger: r.logger.Named(name), rules: r.rules, name: JoinName(r.name, name)}
+}
+
+func (r *ruledLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return r
+	}
+	return &ruledLogger{logger: r.logger.With(fields...), rules: r.rules, name: r.name}
+}
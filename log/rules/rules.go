@@ -0,0 +1,42 @@
+// Package rules contains an http.Handler for viewing and changing log.Rules at
+// runtime, kept apart from package log so that using log.Rules doesn’t require
+// net/http.
+package rules
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/now/x/log"
+)
+
+// Handler lets Rules be viewed with a GET request and changed with a PUT
+// request, both with Rules written as described by log.Rules as a text/plain
+// body.
+type Handler struct {
+	Rules *log.Rules
+}
+
+// ServeHTTP handles req as described by Handler.
+func (h Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPut:
+		b, err := io.ReadAll(io.LimitReader(req.Body, 1<<16))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := h.Rules.Set(string(b)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, h.Rules.String())
+}
@@ -0,0 +1,36 @@
+package rules_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/rules"
+)
+
+func TestHandler(t *testing.T) {
+	var r log.Rules
+	h := rules.Handler{Rules: &r}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("-a.*")))
+	if w.Code != http.StatusOK || w.Body.String() != "-a.*\n" {
+		t.Errorf("PUT = %d %q, want %d %q", w.Code, w.Body.String(), http.StatusOK, "-a.*\n")
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "-a.*\n" {
+		t.Errorf("GET = %d %q, want %d %q", w.Code, w.Body.String(), http.StatusOK, "-a.*\n")
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader("-")))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("PUT of invalid rules = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,101 @@
+package log_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+)
+
+func TestRulesEnabled(t *testing.T) {
+	tests := []struct {
+		rules string
+		name  string
+		want  bool
+	}{
+		{"", "a", true},
+		{"-a", "a", false},
+		{"-a", "ab", true},
+		{"-db.pool.*", "db.pool.conns", false},
+		{"-db.pool.*", "db.pool.conns.idle", false},
+		{"-db.pool.*", "db.pool", true},
+		{"-db.pool.*, db.pool.conns", "db.pool.conns", true},
+		{"-db.pool.*, db.pool.conns", "db.pool.idle", false},
+		{"-*", "", false},
+		{"-*.b.*", "a.b.c", false},
+		{"-*.b.*", "a.c.b", true},
+		{"-a*b*c", "aXbYbZc", false},
+		{"-a*b*c", "aXbYbZcd", true},
+		{"-**a", "ba", false},
+		{"-*a*a*a*a*a*a*a*a*a*a*b", strings.Repeat("a", 40), true},
+	}
+	for _, tt := range tests {
+		var r log.Rules
+		if err := r.Set(tt.rules); err != nil {
+			t.Fatalf("r.Set(%q) = %v, want nil", tt.rules, err)
+		}
+		if got := r.Enabled(tt.name); got != tt.want {
+			t.Errorf("Rules(%q).Enabled(%q) = %v, want %v", tt.rules, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRulesEnabledPathological(t *testing.T) {
+	var r log.Rules
+	r.Set("-*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*a*b")
+	name := strings.Repeat("a", 1000)
+	start := time.Now()
+	r.Enabled(name)
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("r.Enabled(…) took %v, want less than a second", d)
+	}
+}
+
+func TestRulesSet(t *testing.T) {
+	var r log.Rules
+	r.Set("a, -b")
+	if got, want := r.String(), "a,-b"; got != want {
+		t.Errorf("r.String() = %q, want %q", got, want)
+	}
+	if err := r.Set("a,,b"); err == nil {
+		t.Errorf(`r.Set("a,,b") = nil, want error`)
+	} else if got, want := r.String(), "a,-b"; got != want {
+		t.Errorf("r.String() after failed Set = %q, want %q", got, want)
+	}
+	t.Setenv("LOG_RULES", "-c")
+	if err := r.SetFromEnv("LOG_RULES"); err != nil {
+		t.Errorf(`r.SetFromEnv("LOG_RULES") = %v, want nil`, err)
+	} else if got, want := r.String(), "-c"; got != want {
+		t.Errorf("r.String() = %q, want %q", got, want)
+	}
+	if err := r.SetFromEnv("LOG_RULES_UNSET"); err != nil || r.String() != "-c" {
+		t.Errorf(`r.SetFromEnv("LOG_RULES_UNSET") = %v, changed rules to %q`, err, r.String())
+	}
+}
+
+func TestRuled(t *testing.T) {
+	var (
+		rec   log.Recorder
+		rules log.Rules
+	)
+	ctx := log.Using(context.Background(), log.Ruled(&rec, &rules))
+	pool := log.Named(log.Named(ctx, "db"), "pool")
+	log.Entry(pool, "a")
+	rules.Set("-db.pool.*,-db.pool")
+	log.Entry(pool, "b")
+	log.Entry(log.Named(pool, "conns"), "c")
+	log.Entry(log.With(log.Named(ctx, "db"), log.Int("d", 1)), "e")
+	rules.Set("")
+	log.Entry(pool, "f")
+	if diff := cmp.Diff(rec.Entries(), []log.Record{
+		{Name: "db.pool", Message: "a"},
+		{Name: "db", Message: "e", Fields: []log.RecordField{{"d", 1}}},
+		{Name: "db.pool", Message: "f"},
+	}); diff != "" {
+		t.Errorf("rec.Entries() diff -got +want\n%s", diff)
+	}
+}
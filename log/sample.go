@@ -0,0 +1,140 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	xtime "github.com/now/x/time"
+)
+
+// Sampling of the entries made to a Logger created by Sample.
+//
+// Time is divided into intervals of length Interval, as measured by Clock,
+// beginning with the first entry.  During an interval, the First entries with
+// the same name and message are made, followed by every Thereafter entry after
+// that, unless Thereafter is less than one, in which case no more such entries
+// are made.  The remaining entries are suppressed.
+//
+// When an entry is made after an interval has passed, a summary entry is first
+// made for each name and message that had entries suppressed during the
+// interval.  A summary entry is made to a Logger with the same name with the
+// message “entries suppressed” and the Fields “message”, a String of the
+// message, and “suppressed”, an Int of the number of suppressed entries.
+type Sampling struct {
+	Clock      xtime.Clock   // Clock to measure intervals with; time.Now if nil.
+	Interval   time.Duration // Interval to sample over; one second if ≤ 0.
+	First      int           // First entries to make per interval.
+	Thereafter int           // Thereafter make every Thereafter entry.
+}
+
+// Sample is a Logger that samples the entries made to l according to s.
+//
+// Loggers derived from it by Named and With share the sampling state.
+func Sample(l Logger, s Sampling) Logger {
+	if s.Clock == nil {
+		s.Clock = xtime.ClockFunc(time.Now)
+	}
+	if s.Interval <= 0 {
+		s.Interval = time.Second
+	}
+	return sampleLogger{&sampler{s: s}, l, l, ""}
+}
+
+type sampleLogger struct {
+	s *sampler
+
+	// logger makes entries, while named, which lacks any Fields added with
+	// With, makes summary entries.
+	logger Logger
+	named  Logger
+	name   string
+}
+
+func (l sampleLogger) Entry(message string, fields ...Field) error {
+	keep, summaries := l.s.sample(sampleKey{l.name, message}, l.named)
+	var errs []error
+	for _, s := range summaries {
+		err := s.logger.Entry("entries suppressed", String("message", s.message), Int("suppressed", s.suppressed))
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if keep {
+		if err := l.logger.Entry(message, fields...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return joinError(errs)
+	}
+}
+
+func (l sampleLogger) Named(name string) Logger {
+	if name == "" {
+		return l
+	}
+	return sampleLogger{l.s, l.logger.Named(name), l.named.Named(name), JoinName(l.name, name)}
+}
+
+func (l sampleLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return sampleLogger{l.s, l.logger.With(fields...), l.named, l.name}
+}
+
+type sampleKey struct {
+	name    string
+	message string
+}
+
+type sampleCount struct {
+	logger     Logger
+	message    string
+	n          int
+	suppressed int
+}
+
+type sampler struct {
+	s      Sampling
+	mu     sync.Mutex
+	start  time.Time
+	counts map[sampleKey]*sampleCount
+	order  []*sampleCount // order is counts in the order they were added.
+}
+
+// sample counts an entry with k, returning whether to make it and any
+// summaries of the interval that has passed, if any.
+func (s *sampler) sample(k sampleKey, named Logger) (bool, []*sampleCount) {
+	now := s.s.Clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var summaries []*sampleCount
+	if s.counts == nil || now.Sub(s.start) >= s.s.Interval {
+		for _, c := range s.order {
+			if c.suppressed > 0 {
+				summaries = append(summaries, c)
+			}
+		}
+		s.start = now
+		s.counts = make(map[sampleKey]*sampleCount)
+		s.order = nil
+	}
+	c, ok := s.counts[k]
+	if !ok {
+		c = &sampleCount{logger: named, message: k.message}
+		s.counts[k] = c
+		s.order = append(s.order, c)
+	}
+	c.n++
+	if c.n <= s.s.First || (s.s.Thereafter > 0 && (c.n-s.s.First)%s.s.Thereafter == 0) {
+		return true, summaries
+	}
+	c.suppressed++
+	return false, summaries
+}
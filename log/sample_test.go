@@ -0,0 +1,58 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	xtime "github.com/now/x/time"
+)
+
+func TestSample(t *testing.T) {
+	now := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+	var r log.Recorder
+	ctx := log.Using(context.Background(), log.Sample(&r, log.Sampling{
+		Clock:      xtime.ClockFunc(func() time.Time { return now }),
+		Interval:   time.Minute,
+		First:      2,
+		Thereafter: 3,
+	}))
+	db := log.With(log.Named(ctx, "db"), log.Int("a", 1))
+	for i := 1; i <= 8; i++ {
+		log.Entry(db, "retrying", log.Int("attempt", i))
+	}
+	log.Entry(ctx, "retrying")
+	now = now.Add(30 * time.Second)
+	log.Entry(db, "retrying", log.Int("attempt", 9))
+	now = now.Add(30 * time.Second)
+	log.Entry(ctx, "b")
+	now = now.Add(time.Minute)
+	log.Entry(ctx, "c")
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Name: "db", Message: "retrying", Fields: []log.RecordField{{"a", 1}, {"attempt", 1}}},
+		{Name: "db", Message: "retrying", Fields: []log.RecordField{{"a", 1}, {"attempt", 2}}},
+		{Name: "db", Message: "retrying", Fields: []log.RecordField{{"a", 1}, {"attempt", 5}}},
+		{Name: "db", Message: "retrying", Fields: []log.RecordField{{"a", 1}, {"attempt", 8}}},
+		{Message: "retrying"},
+		{Name: "db", Message: "entries suppressed", Fields: []log.RecordField{{"message", "retrying"}, {"suppressed", 5}}},
+		{Message: "b"},
+		{Message: "c"},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+func TestSampleWithoutThereafter(t *testing.T) {
+	var r log.Recorder
+	ctx := xtime.Stopped(context.Background(), time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC))
+	l := log.Sample(&r, log.Sampling{Clock: ctx.Value(xtime.Key).(xtime.Clock), First: 1})
+	for i := 0; i < 3; i++ {
+		l.Entry("a")
+	}
+	if got, want := len(r.Entries()), 1; got != want {
+		t.Errorf("len(r.Entries()) = %d, want %d", got, want)
+	}
+}
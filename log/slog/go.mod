@@ -0,0 +1,9 @@
+module github.com/now/x/log/slog
+
+go 1.21
+
+require github.com/now/x v0.1.0
+
+require github.com/google/go-cmp v0.5.7 // indirect
+
+replace github.com/now/x => ../..
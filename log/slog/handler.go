@@ -0,0 +1,126 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+// Handler is a slog.Handler that delegates to a log.Logger.
+//
+// A slog.Record r is added as an entry with message r.Message, followed by a
+// log.Field “level” with value.String(r.Level.String()), followed by a
+// log.Field for each slog.Attr of r.  The time and source of r are ignored, as
+// they’re left to the log.Logger.
+//
+// A slog.Attr{Key: k, Value: v} is mapped to a log.Field f after resolving any
+// slog.LogValuer as follows:
+//
+// If v is a slog.KindAny of an error err, f = log.Error(err), but with label k.
+//
+// If v is a slog.KindAny of any other value a, f = log.Reflect(k, a).
+//
+// If v is a slog.KindBool of b, f = log.Bool(k, b).
+//
+// If v is a slog.KindDuration of d, f = log.Duration(k, d).
+//
+// If v is a slog.KindFloat64 of x, f = log.Float64(k, x).
+//
+// If v is a slog.KindInt64 of i, f = log.Int64(k, i).
+//
+// If v is a slog.KindString of s, f = log.String(k, s).
+//
+// If v is a slog.KindTime of t, f = log.Time(k, t).
+//
+// If v is a slog.KindUint64 of u, f = log.Reflect(k, u).
+//
+// If v is a slog.KindGroup of attributes, f = log.Object(k, fields...), where
+// fields are the attributes mapped as above.  If k is empty, the fields are
+// inlined instead.  Groups without attributes are ignored.
+//
+// Empty slog.Attrs are ignored.  Groups added with WithGroup(name) are mapped
+// to Logger.Named(name).
+type Handler struct {
+	Logger log.Logger
+
+	// Level that records must be at or above to be handled.  All records are
+	// handled if it’s nil.
+	Level slog.Leveler
+}
+
+// Enabled is true if h.Level is nil or level ≥ h.Level.Level().
+func (h Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.Level == nil || level >= h.Level.Level()
+}
+
+// Handle delegates to h.Logger.Entry(r.Message, fields...).
+//
+// Errors if h.Logger.Entry(…) errors.
+func (h Handler) Handle(_ context.Context, r slog.Record) error {
+	fields := make([]log.Field, 0, 1+r.NumAttrs())
+	fields = append(fields, log.String("level", r.Level.String()))
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendFields(fields, a)
+		return true
+	})
+	return h.Logger.Entry(r.Message, fields...)
+}
+
+// WithAttrs is a new Handler delegating to h.Logger.With(fields...).
+func (h Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var fields []log.Field
+	for _, a := range attrs {
+		fields = appendFields(fields, a)
+	}
+	return Handler{h.Logger.With(fields...), h.Level}
+}
+
+// WithGroup is a new Handler delegating to h.Logger.Named(name).
+func (h Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return Handler{h.Logger.Named(name), h.Level}
+}
+
+func appendFields(fields []log.Field, a slog.Attr) []log.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+	label := a.Key
+	switch v := a.Value; v.Kind() {
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, log.Field{Label: label, Value: value.Error{Err: err}})
+		}
+		return append(fields, log.Reflect(label, v.Any()))
+	case slog.KindBool:
+		return append(fields, log.Bool(label, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, log.Duration(label, v.Duration()))
+	case slog.KindFloat64:
+		return append(fields, log.Float64(label, v.Float64()))
+	case slog.KindInt64:
+		return append(fields, log.Int64(label, v.Int64()))
+	case slog.KindString:
+		return append(fields, log.String(label, v.String()))
+	case slog.KindTime:
+		return append(fields, log.Time(label, v.Time()))
+	case slog.KindGroup:
+		var group []log.Field
+		for _, ga := range v.Group() {
+			group = appendFields(group, ga)
+		}
+		if len(group) == 0 {
+			return fields
+		} else if label == "" {
+			return append(fields, group...)
+		}
+		return append(fields, log.Object(label, group...))
+	default:
+		return append(fields, log.Reflect(label, v.Any()))
+	}
+}
@@ -0,0 +1,72 @@
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/now/x/log"
+	xslog "github.com/now/x/log/slog"
+)
+
+func TestHandler(t *testing.T) {
+	t.Run("maps slog.Attrs to log.Fields", func(t *testing.T) {
+		tests := []struct {
+			attr slog.Attr
+			want string
+		}{
+			{slog.Any("err", fmt.Errorf("failed")), `err=failed`},
+			{slog.Any("values", map[string]int{"a": 1}), `values=map[a:1]`},
+			{slog.Bool("ok", true), `ok=true`},
+			{slog.Duration("elapsed", time.Second), `elapsed=1s`},
+			{slog.Float64("ratio", 0.5), `ratio=0.5`},
+			{slog.Int("ID", 1), `ID=1`},
+			{slog.Int64("neg", -1), `neg=-1`},
+			{slog.String("name", "something"), `name=something`},
+			{slog.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), `at=2022-03-09T19:51:00Z`},
+			{slog.Uint64("ID", 2), `ID=2`},
+			{slog.Group("g", slog.Int("a", 1), slog.Group("h", slog.Int("b", 2))), `g.a=1 g.h.b=2`},
+			{slog.Group("", slog.Int("a", 1)), `a=1`},
+			{slog.Group("g"), ``},
+			{slog.Any("valuer", valuer{}), `valuer=valued`},
+			{slog.Attr{}, ``},
+		}
+		for _, tt := range tests {
+			var b bytes.Buffer
+			h := xslog.Handler{Logger: log.Logfmt(&b)}
+			slog.New(h).LogAttrs(context.Background(), slog.LevelInfo, "abc", tt.attr)
+			want := strings.TrimSuffix(fmt.Sprintf("msg=abc level=INFO %s", tt.want), " ") + "\n"
+			if got := b.String(); got != want {
+				t.Errorf("slog.New(slog.Handler{…}).LogAttrs(…, %v) = %#v, want %#v", tt.attr, got, want)
+			}
+		}
+	})
+
+	t.Run("maps groups to names", func(t *testing.T) {
+		var b bytes.Buffer
+		slog.New(xslog.Handler{Logger: log.Logfmt(&b)}).WithGroup("a").With("b", 1).WithGroup("").WithGroup("c").Warn("d", "e", 2)
+		if got, want := b.String(), "msg=d logger=a.c b=1 level=WARN e=2\n"; got != want {
+			t.Errorf("slog.New(slog.Handler{…}).WithGroup(\"a\")….Warn(…) = %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("respects Level", func(t *testing.T) {
+		var b bytes.Buffer
+		l := slog.New(xslog.Handler{Logger: log.Logfmt(&b), Level: slog.LevelWarn})
+		l.Info("a")
+		l.Error("b")
+		if got, want := b.String(), "msg=b level=ERROR\n"; got != want {
+			t.Errorf("slog.New(slog.Handler{…, Level: slog.LevelWarn}).… = %#v, want %#v", got, want)
+		}
+	})
+}
+
+type valuer struct{}
+
+func (valuer) LogValue() slog.Value {
+	return slog.StringValue("valued")
+}
@@ -0,0 +1,201 @@
+package slog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+// Logger delegates to a slog.Handler.
+//
+// An entry is handled as a slog.Record at slog.LevelInfo, timed at
+// time.Now(), if Handler is enabled at that level.  The record’s attributes
+// are a slog.Attr “logger” with the name of the Logger, if the name isn’t
+// empty, followed by a slog.Attr for each log.Field, beginning with those added
+// to the Logger, then the given fields.
+//
+// A log.Field{Label: l, Value: v} is mapped to a slog.Attr a as follows:
+//
+// If v = value.Error{Err: err}, a = slog.Any(l, err).
+//
+// Otherwise, a = slog.Attr{Key: l, Value: x}, where x depends on what v
+// writes: a value.Bool b is slog.BoolValue(b), a value.Bytes b is
+// slog.AnyValue(b), a value.Duration d is slog.DurationValue(d), a
+// value.Float64 f is slog.Float64Value(f), a value.Int or value.Int64 i is
+// slog.Int64Value(i), a value.String s is slog.StringValue(s), a value.Time t
+// is slog.TimeValue(t), a value.Reflect{Value: r} is slog.AnyValue(r), a
+// value.Object or Fields are slog.GroupValue(…) of the Fields mapped as above,
+// and a value.Array is a slog.AnyValue of a slice of its elements.  If v writes
+// more than one value, x is a slog.AnyValue of a slice of the values.
+type Logger struct {
+	Handler slog.Handler
+	name    string
+	fields  []log.Field
+}
+
+// Entry delegates to l.Handler.Handle(…).
+//
+// Errors if a field can’t be written or if l.Handler.Handle(…) errors.
+func (l Logger) Entry(message string, fields ...log.Field) error {
+	ctx := context.Background()
+	if !l.Handler.Enabled(ctx, slog.LevelInfo) {
+		return nil
+	}
+	r := slog.NewRecord(time.Now(), slog.LevelInfo, message, 0)
+	if l.name != "" {
+		r.AddAttrs(slog.String("logger", l.name))
+	}
+	for _, fs := range [][]log.Field{l.fields, fields} {
+		for _, f := range fs {
+			a, err := attr(f)
+			if err != nil {
+				return err
+			}
+			r.AddAttrs(a)
+		}
+	}
+	return l.Handler.Handle(ctx, r)
+}
+
+// Named is a new Logger whose name is name, if l’s name is empty, or l’s name,
+// a period, and name, otherwise.
+func (l Logger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	return Logger{l.Handler, name, l.fields}
+}
+
+// With is a new Logger with l’s fields and fields.
+func (l Logger) With(fields ...log.Field) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return Logger{l.Handler, l.name, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+func attr(f log.Field) (slog.Attr, error) {
+	if v, ok := f.Value.(value.Error); ok {
+		return slog.Any(f.Label, v.Err), nil
+	}
+	var w attrWriter
+	if err := f.Write(&w); err != nil {
+		return slog.Attr{}, err
+	}
+	return w.attrs[0], nil
+}
+
+type attrWriter struct {
+	values []slog.Value
+	attrs  []slog.Attr
+}
+
+func (w *attrWriter) Bool(b bool) error {
+	w.values = append(w.values, slog.BoolValue(b))
+	return nil
+}
+
+func (w *attrWriter) Float64(f float64) error {
+	w.values = append(w.values, slog.Float64Value(f))
+	return nil
+}
+
+func (w *attrWriter) Duration(d time.Duration) error {
+	w.values = append(w.values, slog.DurationValue(d))
+	return nil
+}
+
+func (w *attrWriter) Time(t time.Time) error {
+	w.values = append(w.values, slog.TimeValue(t))
+	return nil
+}
+
+func (w *attrWriter) Binary(b []byte) error {
+	w.values = append(w.values, slog.AnyValue(b))
+	return nil
+}
+
+func (w *attrWriter) Int(i int) error {
+	return w.Int64(int64(i))
+}
+
+func (w *attrWriter) Int64(i int64) error {
+	w.values = append(w.values, slog.Int64Value(i))
+	return nil
+}
+
+func (w *attrWriter) Reflect(r interface{}) error {
+	w.values = append(w.values, slog.AnyValue(r))
+	return nil
+}
+
+func (w *attrWriter) String(s string) error {
+	w.values = append(w.values, slog.StringValue(s))
+	return nil
+}
+
+func (w *attrWriter) Object(f func(value.Writer) error) error {
+	var ow attrWriter
+	err := f(&ow)
+	w.values = append(w.values, slog.GroupValue(ow.attrs...))
+	return err
+}
+
+func (w *attrWriter) Array(f func(value.Writer) error) error {
+	var aw attrWriter
+	err := f(&aw)
+	w.values = append(w.values, slog.AnyValue(aw.any()))
+	return err
+}
+
+func (w *attrWriter) Field(label string, f func(value.Writer) error) error {
+	var fw attrWriter
+	err := f(&fw)
+	w.attrs = append(w.attrs, slog.Attr{Key: label, Value: fw.value()})
+	return err
+}
+
+func (w *attrWriter) value() slog.Value {
+	switch {
+	case len(w.attrs) > 0:
+		return slog.GroupValue(w.attrs...)
+	case len(w.values) == 0:
+		return slog.StringValue("")
+	case len(w.values) == 1:
+		return w.values[0]
+	default:
+		return slog.AnyValue(w.any())
+	}
+}
+
+func (w *attrWriter) any() []interface{} {
+	values := make([]interface{}, len(w.values))
+	for i, v := range w.values {
+		if v.Kind() == slog.KindGroup {
+			values[i] = groupMap(v.Group())
+		} else {
+			values[i] = v.Any()
+		}
+	}
+	return values
+}
+
+// groupMap is attrs as a map, so that a group inside a slog.AnyValue is
+// marshaled as an object.
+func groupMap(attrs []slog.Attr) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		if a.Value.Kind() == slog.KindGroup {
+			m[a.Key] = groupMap(a.Value.Group())
+		} else {
+			m[a.Key] = a.Value.Any()
+		}
+	}
+	return m
+}
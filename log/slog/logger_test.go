@@ -0,0 +1,127 @@
+package slog_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/now/x/log"
+	xslog "github.com/now/x/log/slog"
+	"github.com/now/x/log/value"
+)
+
+func TestLoggerEntry(t *testing.T) {
+	t.Run("maps log.Fields to slog.Attrs", func(t *testing.T) {
+		tests := []struct {
+			field log.Field
+			want  string
+		}{
+			{log.Bool("ok", true), `"ok":true`},
+			{log.Bytes("data", []byte("abc")), `"data":"YWJj"`},
+			{log.Duration("elapsed", time.Second), `"elapsed":1000000000`},
+			{log.Error(fmt.Errorf("failed")), `"error":"failed"`},
+			{log.Float64("ratio", 0.5), `"ratio":0.5`},
+			{log.Int("ID", 1), `"ID":1`},
+			{log.Int64("neg", -1), `"neg":-1`},
+			{log.Reflect("values", map[string]int{"a": 1}), `"values":{"a":1}`},
+			{log.String("name", "something"), `"name":"something"`},
+			{log.Stringer("string", stringer("stringed")), `"string":"stringed"`},
+			{log.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), `"at":"2022-03-09T19:51:00Z"`},
+			{log.Field{Label: "group", Value: field{log.Int("a", 1)}}, `"group":{"a":1}`},
+			{log.Object("object", log.Int("a", 1), log.Object("b", log.String("c", "d"))), `"object":{"a":1,"b":{"c":"d"}}`},
+			{log.Struct("user", struct {
+				Name     string `log:"name"`
+				Password string `log:",redact"`
+			}{"a", "b"}), `"user":{"name":"a","Password":"[REDACTED]"}`},
+			{log.Array("array", value.Int(1), value.Object{log.Int("a", 2)}), `"array":[1,{"a":2}]`},
+			{log.Field{Label: "values", Value: values{1, 2}}, `"values":[1,2]`},
+		}
+		for _, tt := range tests {
+			var b bytes.Buffer
+			if err := logger(&b).Entry("abc", tt.field); err != nil {
+				t.Errorf("slog.Logger{…}.Entry(\"abc\", %#v) = %v, want nil", tt.field, err)
+			} else if got, want := b.String(), fmt.Sprintf("{\"level\":\"INFO\",\"msg\":\"abc\",%s}\n", tt.want); got != want {
+				t.Errorf("slog.Logger{…}.Entry(\"abc\", %#v) = %#v, want %#v", tt.field, got, want)
+			}
+		}
+	})
+
+	t.Run("errors if a field can’t be written", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := logger(&b).Entry("abc", log.Stringer("s", stringerPanicker{})); err == nil {
+			t.Errorf("slog.Logger{…}.Entry(\"abc\", …) = nil, want error")
+		} else if b.Len() != 0 {
+			t.Errorf("slog.Logger{…}.Entry(\"abc\", …) wrote %#v, want nothing", b.String())
+		}
+	})
+
+	t.Run("skips disabled entries", func(t *testing.T) {
+		var b bytes.Buffer
+		l := xslog.Logger{Handler: slog.NewJSONHandler(&b, &slog.HandlerOptions{Level: slog.LevelWarn})}
+		if err := l.Entry("abc"); err != nil {
+			t.Errorf("slog.Logger{…}.Entry(\"abc\") = %v, want nil", err)
+		} else if b.Len() != 0 {
+			t.Errorf("slog.Logger{…}.Entry(\"abc\") wrote %#v, want nothing", b.String())
+		}
+	})
+}
+
+func TestLoggerNamed(t *testing.T) {
+	var b bytes.Buffer
+	logger(&b).Named("a").Named("").Named("b").Entry("c")
+	if got, want := b.String(), "{\"level\":\"INFO\",\"msg\":\"c\",\"logger\":\"a.b\"}\n"; got != want {
+		t.Errorf(`slog.Logger{…}.Named("a")….Entry("c") = %#v, want %#v`, got, want)
+	}
+}
+
+func TestLoggerWith(t *testing.T) {
+	var b bytes.Buffer
+	l := logger(&b).With(log.Int64("ID", 1))
+	l.With().With(log.Int("a", 2)).Entry("bc", log.Int("d", 3))
+	l.Entry("e")
+	if got, want := b.String(), "{\"level\":\"INFO\",\"msg\":\"bc\",\"ID\":1,\"a\":2,\"d\":3}\n{\"level\":\"INFO\",\"msg\":\"e\",\"ID\":1}\n"; got != want {
+		t.Errorf("slog.Logger{…}.With(log.Int64(\"ID\", 1))….Entry(…) = %#v, want %#v", got, want)
+	}
+}
+
+func logger(b *bytes.Buffer) xslog.Logger {
+	return xslog.Logger{
+		Handler: slog.NewJSONHandler(b, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if len(groups) == 0 && a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}),
+	}
+}
+
+type field struct {
+	log.Field
+}
+
+type values []int
+
+func (vs values) Write(w value.Writer) error {
+	for _, v := range vs {
+		if err := w.Int(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stringer string
+
+func (s stringer) String() string {
+	return string(s)
+}
+
+type stringerPanicker struct{}
+
+func (stringerPanicker) String() string {
+	panic("oh, no!")
+}
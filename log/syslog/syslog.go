@@ -0,0 +1,279 @@
+// Package syslog contains a Logger that sends entries to a syslog server as
+// RFC 5424 messages.
+package syslog
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+	xtime "github.com/now/x/time"
+)
+
+// Config of a Logger.
+type Config struct {
+	// Network and Address of the syslog server, as used by net.Dial.  Network
+	// is one of “unixgram”, “udp”, “udp4”, and “udp6”, in which case each
+	// message is sent as a datagram, or one of “unix”, “tcp”, “tcp4”, and
+	// “tcp6”, in which case messages are framed by octet counting, as per RFC
+	// 6587.
+	Network string
+	Address string
+
+	// Priority of messages, that is, their facility times eight plus their
+	// severity, 14, user-level and informational, if 0.
+	Priority int
+
+	// Hostname of messages, os.Hostname() if empty.
+	Hostname string
+
+	// AppName of messages, the base name of os.Args[0] if empty.
+	AppName string
+
+	// SDID is the SD-ID of the STRUCTURED-DATA element of Fields,
+	// “fields@32473” if empty.
+	SDID string
+
+	// Clock to timestamp messages with, time.Now if nil.
+	Clock xtime.Clock
+}
+
+// Logger that sends entries to a syslog server as RFC 5424 messages.
+//
+// An entry is sent as a message with the Priority, Hostname, and AppName of the
+// Config, the time of the entry, the process ID, the name of the Logger as
+// MSGID, a STRUCTURED-DATA element with the SD-ID of the Config and a parameter
+// for each Field, beginning with those added to the Logger, then the given
+// fields, and the message, prefixed by a byte-order mark, as MSG.  Header
+// fields that are empty are written as “-”, while characters outside of
+// printable US-ASCII are replaced by “_”, and header fields are truncated to
+// their maximum lengths.  The name of a parameter is the label of the Field,
+// modified in the same way, and the value is what the Field’s Value writes to a
+// value.BytesWriter.
+//
+// If sending a message fails, the connection is redialed and the message is
+// resent once.
+//
+// A Logger is safe for concurrent use.
+type Logger struct {
+	c      *conn
+	name   string
+	fields []log.Field
+}
+
+// Dial is a new Logger that sends entries to the syslog server of c.
+//
+// Errors if the syslog server can’t be dialed.
+func Dial(c Config) (*Logger, error) {
+	if c.Priority == 0 {
+		c.Priority = 14
+	}
+	if c.Hostname == "" {
+		c.Hostname, _ = os.Hostname()
+	}
+	if c.AppName == "" && len(os.Args) > 0 {
+		c.AppName = filepath.Base(os.Args[0])
+	}
+	if c.SDID == "" {
+		c.SDID = "fields@32473"
+	}
+	if c.Clock == nil {
+		c.Clock = xtime.ClockFunc(time.Now)
+	}
+	cn := &conn{c: c, pid: strconv.Itoa(os.Getpid())}
+	if err := cn.dial(); err != nil {
+		return nil, err
+	}
+	return &Logger{c: cn}, nil
+}
+
+// Entry sends message and fields to the syslog server.
+//
+// Errors if a field errors when being written or if the message can’t be
+// sent.
+func (l *Logger) Entry(message string, fields ...log.Field) error {
+	m, err := l.c.message(l.name, message, [2][]log.Field{l.fields, fields})
+	if err != nil {
+		return err
+	}
+	return l.c.send(m)
+}
+
+// Named is a new Logger that shares l’s connection.
+func (l *Logger) Named(name string) log.Logger {
+	if name == "" {
+		return l
+	}
+	return &Logger{l.c, log.JoinName(l.name, name), l.fields}
+}
+
+// With is a new Logger that shares l’s connection.
+func (l *Logger) With(fields ...log.Field) log.Logger {
+	if len(fields) == 0 {
+		return l
+	}
+	return &Logger{l.c, l.name, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+}
+
+// Close the connection to the syslog server.
+func (l *Logger) Close() error {
+	return l.c.close()
+}
+
+type conn struct {
+	c   Config
+	pid string
+
+	mu sync.Mutex
+	nc net.Conn
+}
+
+func (c *conn) dial() error {
+	nc, err := net.Dial(c.c.Network, c.c.Address)
+	if err != nil {
+		return fmt.Errorf("syslog: %w", err)
+	}
+	c.nc = nc
+	return nil
+}
+
+func (c *conn) send(m []byte) error {
+	switch c.c.Network {
+	case "unix", "tcp", "tcp4", "tcp6":
+		m = append(strconv.AppendInt(nil, int64(len(m)), 10), append([]byte{' '}, m...)...)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc != nil {
+		if _, err := c.nc.Write(m); err == nil {
+			return nil
+		}
+		c.nc.Close()
+		c.nc = nil
+	}
+	if err := c.dial(); err != nil {
+		return err
+	}
+	if _, err := c.nc.Write(m); err != nil {
+		c.nc.Close()
+		c.nc = nil
+		return fmt.Errorf("syslog: %w", err)
+	}
+	return nil
+}
+
+func (c *conn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.nc == nil {
+		return nil
+	}
+	err := c.nc.Close()
+	c.nc = nil
+	return err
+}
+
+// message is the RFC 5424 message of an entry.
+func (c *conn) message(name, message string, fieldss [2][]log.Field) ([]byte, error) {
+	b := make([]byte, 0, 256)
+	b = append(b, '<')
+	b = strconv.AppendInt(b, int64(c.c.Priority), 10)
+	b = append(b, ">1 "...)
+	b = c.c.Clock.Now().UTC().AppendFormat(b, "2006-01-02T15:04:05.000000Z07:00")
+	b = append(b, ' ')
+	b = appendHeader(b, c.c.Hostname, 255)
+	b = append(b, ' ')
+	b = appendHeader(b, c.c.AppName, 48)
+	b = append(b, ' ')
+	b = appendHeader(b, c.pid, 128)
+	b = append(b, ' ')
+	b = appendHeader(b, name, 32)
+	b = append(b, ' ')
+	sd := len(fieldss[0])+len(fieldss[1]) > 0
+	if !sd {
+		b = append(b, '-')
+	} else {
+		b = append(b, '[')
+		b = appendName(b, c.c.SDID)
+	}
+	for _, fields := range fieldss {
+		for _, f := range fields {
+			var w value.BytesWriter
+			if err := f.Value.Write(&w); err != nil {
+				return nil, err
+			}
+			b = append(b, ' ')
+			b = appendName(b, f.Label)
+			b = append(b, '=', '"')
+			b = appendParamValue(b, w.Bytes)
+			b = append(b, '"')
+		}
+	}
+	if sd {
+		b = append(b, ']')
+	}
+	if message != "" {
+		b = append(b, " \xEF\xBB\xBF"...)
+		b = append(b, message...)
+	}
+	return b, nil
+}
+
+// appendHeader appends s to b as a header field of at most max characters.
+func appendHeader(b []byte, s string, max int) []byte {
+	if s == "" {
+		return append(b, '-')
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	return appendPrintable(b, s)
+}
+
+// appendName appends s to b as an SD-NAME.
+func appendName(b []byte, s string) []byte {
+	if s == "" {
+		return append(b, '_')
+	}
+	if len(s) > 32 {
+		s = s[:32]
+	}
+	n := len(b)
+	b = appendPrintable(b, s)
+	for i := n; i < len(b); i++ {
+		switch b[i] {
+		case '=', ']', '"':
+			b[i] = '_'
+		}
+	}
+	return b
+}
+
+func appendPrintable(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 33 || c > 126 {
+			b = append(b, '_')
+		} else {
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// appendParamValue appends v to b as a PARAM-VALUE.
+func appendParamValue(b []byte, v []byte) []byte {
+	for _, c := range v {
+		switch c {
+		case '"', '\\', ']':
+			b = append(b, '\\')
+		}
+		b = append(b, c)
+	}
+	return b
+}
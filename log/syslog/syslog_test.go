@@ -0,0 +1,164 @@
+package syslog_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/syslog"
+	xtime "github.com/now/x/time"
+)
+
+func config(network, address string) syslog.Config {
+	return syslog.Config{
+		Network:  network,
+		Address:  address,
+		Hostname: "host",
+		AppName:  "app",
+		Clock:    xtime.StoppedAt(time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)),
+	}
+}
+
+var header = "<14>1 2022-03-09T19:51:00.000000Z host app " + strconv.Itoa(os.Getpid())
+
+func TestLoggerUDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	l, err := syslog.Dial(config("udp", pc.LocalAddr().String()))
+	if err != nil {
+		t.Fatalf("syslog.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	tests := []struct {
+		logger  log.Logger
+		message string
+		fields  []log.Field
+		want    string
+	}{
+		{l, "a", nil, header + " - - \ufeffa"},
+		{l.Named("db").Named("pool"), "b", []log.Field{log.Int("c", 1)}, header + ` db.pool [fields@32473 c="1"] ` + "\ufeffb"},
+		{l.With(log.String("d e", `f"]\`)), "", []log.Field{log.Object("g", log.Int("h", 2))}, header + ` - [fields@32473 d_e="f\"\]\\" g="{h: 2}"]`},
+	}
+	for _, tt := range tests {
+		if err := tt.logger.Entry(tt.message, tt.fields...); err != nil {
+			t.Fatalf("l.Entry(%q, …) = %v, want nil", tt.message, err)
+		}
+		b := make([]byte, 1024)
+		pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := pc.ReadFrom(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b[:n]); got != tt.want {
+			t.Errorf("l.Entry(%q, …) sent %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestLoggerUnixgram(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+	pc, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram unsupported: %v", err)
+	}
+	defer pc.Close()
+	l, err := syslog.Dial(config("unixgram", path))
+	if err != nil {
+		t.Fatalf("syslog.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+	l.Entry("a")
+	b := make([]byte, 1024)
+	n, _, err := pc.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b[:n]), header+" - - \ufeffa"; got != want {
+		t.Errorf(`l.Entry("a") sent %q, want %q`, got, want)
+	}
+}
+
+func TestLoggerTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	l, err := syslog.Dial(config("tcp", ln.Addr().String()))
+	if err != nil {
+		t.Fatalf("syslog.Dial(…) = _, %v, want nil", err)
+	}
+	defer l.Close()
+
+	c, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Entry("a"); err != nil {
+		t.Fatalf(`l.Entry("a") = %v, want nil`, err)
+	}
+	if got, want := readFrame(t, bufio.NewReader(c)), header+" - - \ufeffa"; got != want {
+		t.Errorf(`l.Entry("a") sent %q, want %q`, got, want)
+	}
+
+	// The server going away makes writes fail eventually, at which point the
+	// Logger should redial.
+	c.Close()
+	accepted := make(chan net.Conn)
+	go func() {
+		c, err := ln.Accept()
+		if err == nil {
+			accepted <- c
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		if err := l.Entry("b"); err != nil {
+			t.Fatalf(`l.Entry("b") = %v, want nil`, err)
+		}
+		select {
+		case c := <-accepted:
+			defer c.Close()
+			if got, want := readFrame(t, bufio.NewReader(c)), header+" - - \ufeffb"; got != want {
+				t.Errorf(`l.Entry("b") sent %q, want %q`, got, want)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("l.Entry(…) didn’t redial")
+}
+
+func readFrame(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	s, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(s, " "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestDialError(t *testing.T) {
+	_, err := syslog.Dial(config("unixgram", filepath.Join(t.TempDir(), "missing")))
+	if err == nil || !strings.HasPrefix(err.Error(), "syslog: ") {
+		t.Errorf("syslog.Dial(…) = _, %v, want syslog error", err)
+	}
+}
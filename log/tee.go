@@ -0,0 +1,111 @@
+package log
+
+import "strings"
+
+// Tee is a Logger that makes entries to each of loggers.
+//
+// Entry makes the entry to each of loggers in turn, even if one of them errors.
+// Named and With are likewise forwarded to each of loggers.
+//
+// Use Filter to only make some entries to one of loggers.
+func Tee(loggers ...Logger) Logger {
+	return teeLogger(loggers)
+}
+
+type teeLogger []Logger
+
+// Entry errors with the errors of the loggers, if any, joined like
+// errors.Join, so that they can be inspected by errors.Is and errors.As.
+func (t teeLogger) Entry(message string, fields ...Field) error {
+	var errs []error
+	for _, l := range t {
+		if err := l.Entry(message, fields...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return joinError(errs)
+}
+
+func (t teeLogger) Named(name string) Logger {
+	if name == "" {
+		return t
+	}
+	n := make(teeLogger, len(t))
+	for i, l := range t {
+		n[i] = l.Named(name)
+	}
+	return n
+}
+
+func (t teeLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return t
+	}
+	n := make(teeLogger, len(t))
+	for i, l := range t {
+		n[i] = l.With(fields...)
+	}
+	return n
+}
+
+// joinError is errors joined like errors.Join, which isn’t available in all
+// supported versions of Go.
+type joinError []error
+
+func (e joinError) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (e joinError) Unwrap() []error {
+	return e
+}
+
+// Predicate over an entry made to a Logger with name, message, and fields,
+// beginning with those added to the Logger, then those of the entry.
+type Predicate func(name, message string, fields []Field) bool
+
+// Filter is a Logger that only makes the entries for which keep is true to l.
+//
+// Loggers derived from it by Named and With are filtered by keep as well.
+func Filter(l Logger, keep Predicate) Logger {
+	return filterLogger{logger: l, keep: keep}
+}
+
+type filterLogger struct {
+	logger Logger
+	keep   Predicate
+	name   string
+	fields []Field
+}
+
+func (f filterLogger) Entry(message string, fields ...Field) error {
+	all := fields
+	if len(f.fields) > 0 {
+		all = append(f.fields[:len(f.fields):len(f.fields)], fields...)
+	}
+	if !f.keep(f.name, message, all) {
+		return nil
+	}
+	return f.logger.Entry(message, fields...)
+}
+
+func (f filterLogger) Named(name string) Logger {
+	if name == "" {
+		return f
+	}
+	return filterLogger{f.logger.Named(name), f.keep, JoinName(f.name, name), f.fields}
+}
+
+func (f filterLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return f
+	}
+	return filterLogger{f.logger.With(fields...), f.keep, f.name, append(f.fields[:len(f.fields):len(f.fields)], fields...)}
+}
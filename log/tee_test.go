@@ -0,0 +1,77 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	"github.com/now/x/log/value"
+)
+
+func TestTee(t *testing.T) {
+	t.Run("forwards to each Logger", func(t *testing.T) {
+		var r1, r2 log.Recorder
+		ctx := log.Using(context.Background(), log.Tee(&r1, &r2))
+		log.Entry(log.With(log.Named(ctx, "a"), log.Int("b", 1)), "c", log.String("d", "e"))
+		want := []log.Record{{Name: "a", Message: "c", Fields: []log.RecordField{{"b", 1}, {"d", "e"}}}}
+		for i, r := range []*log.Recorder{&r1, &r2} {
+			if diff := cmp.Diff(r.Entries(), want); diff != "" {
+				t.Errorf("r%d.Entries() diff -got +want\n%s", i+1, diff)
+			}
+		}
+	})
+
+	t.Run("joins errors", func(t *testing.T) {
+		var r log.Recorder
+		err1, err2 := errors.New("a"), errors.New("b")
+		err := log.Tee(failing{err1}, &r, failing{err2}).Entry("c")
+		if err == nil || err.Error() != "a\nb" {
+			t.Errorf(`log.Tee(…).Entry("c") = %v, want "a\nb"`, err)
+		}
+		if !errors.Is(err, err1) || !errors.Is(err, err2) {
+			t.Errorf(`errors.Is(log.Tee(…).Entry("c"), …) = false, want true`)
+		}
+		if got := len(r.Entries()); got != 1 {
+			t.Errorf("len(r.Entries()) = %d, want 1", got)
+		}
+		if err := log.Tee(&r).Entry("d"); err != nil {
+			t.Errorf(`log.Tee(&r).Entry("d") = %v, want nil`, err)
+		}
+	})
+}
+
+func TestFilter(t *testing.T) {
+	var all, errs log.Recorder
+	hasError := func(_, _ string, fields []log.Field) bool {
+		for _, f := range fields {
+			if _, ok := f.Value.(value.Error); ok {
+				return true
+			}
+		}
+		return false
+	}
+	ctx := log.Using(context.Background(), log.Tee(&all, log.Filter(&errs, hasError)))
+	log.Entry(ctx, "a")
+	log.Entry(ctx, "b", log.Error(errors.New("c")))
+	log.Entry(log.With(log.Named(ctx, "d"), log.Error(errors.New("e"))), "f")
+	if got, want := len(all.Entries()), 3; got != want {
+		t.Errorf("len(all.Entries()) = %d, want %d", got, want)
+	}
+	if diff := cmp.Diff(errs.Entries(), []log.Record{
+		{Message: "b", Fields: []log.RecordField{{"error", "c"}}},
+		{Name: "d", Message: "f", Fields: []log.RecordField{{"error", "e"}}},
+	}); diff != "" {
+		t.Errorf("errs.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+type failing struct {
+	err error
+}
+
+func (f failing) Entry(string, ...log.Field) error { return f.err }
+func (f failing) Named(string) log.Logger          { return f }
+func (f failing) With(...log.Field) log.Logger     { return f }
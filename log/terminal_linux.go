@@ -0,0 +1,14 @@
+package log
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal is true if fd is a terminal, that is, if it has terminal
+// attributes.
+func isTerminal(fd uintptr) bool {
+	var t syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TCGETS, uintptr(unsafe.Pointer(&t)))
+	return errno == 0
+}
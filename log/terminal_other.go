@@ -0,0 +1,9 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !windows
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!windows
+
+package log
+
+// isTerminal is false, as terminals aren’t detected on this platform.
+func isTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,9 @@
+package log
+
+import "syscall"
+
+// isTerminal is true if fd is a console, that is, if it has a console mode.
+func isTerminal(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
@@ -0,0 +1,48 @@
+package log
+
+import (
+	"context"
+	"time"
+
+	xtime "github.com/now/x/time"
+)
+
+// Timed begins timing operation and returns a function that ends it.
+//
+// When the returned function is called with an error err, an entry is added
+// to In(ctx) consisting of the message operation and fields, followed by a
+// Field “duration”, a value.Duration of the time that passed since Timed was
+// called, a Field “outcome”, a value.String of “success”, if err is nil, and
+// “failure”, otherwise, and, if err isn’t nil, Error(err).  Errors if adding
+// the entry errors.
+//
+// Time is measured with xtime.In(ctx), so ctx must have a Clock, which also
+// allows for controlling the duration during testing.
+func Timed(ctx context.Context, operation string, fields ...Field) func(error) error {
+	start := xtime.In(ctx)
+	return func(err error) error {
+		return Entry(ctx, operation, timedFields(ctx, start, fields, err)...)
+	}
+}
+
+// Start is like Timed, but ctxʹ = Named(ctx, operation) and entries are added
+// to In(ctxʹ), which is also returned, so that operation can be nested.  An
+// entry consisting of the message “started” and fields is added immediately,
+// ignoring any error, and the entry added by the returned function has the
+// message “finished”.
+func Start(ctx context.Context, operation string, fields ...Field) (context.Context, func(error) error) {
+	ctx = Named(ctx, operation)
+	start := xtime.In(ctx)
+	Entry(ctx, "started", fields...)
+	return ctx, func(err error) error {
+		return Entry(ctx, "finished", timedFields(ctx, start, fields, err)...)
+	}
+}
+
+func timedFields(ctx context.Context, start time.Time, fields []Field, err error) []Field {
+	fields = append(fields[:len(fields):len(fields)], Duration("duration", xtime.In(ctx).Sub(start)))
+	if err != nil {
+		return append(fields, String("outcome", "failure"), Error(err))
+	}
+	return append(fields, String("outcome", "success"))
+}
@@ -0,0 +1,61 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	xtime "github.com/now/x/time"
+)
+
+func TestTimed(t *testing.T) {
+	now := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+	var r log.Recorder
+	ctx := xtime.Using(log.Using(context.Background(), &r), xtime.ClockFunc(func() time.Time { return now }))
+	done := log.Timed(ctx, "a", log.Int("b", 1))
+	now = now.Add(time.Second)
+	if err := done(nil); err != nil {
+		t.Errorf("done(nil) = %v, want nil", err)
+	}
+	done = log.Timed(ctx, "c")
+	now = now.Add(2 * time.Second)
+	done(errors.New("failed"))
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Message: "a", Fields: []log.RecordField{{"b", 1}, {"duration", time.Second}, {"outcome", "success"}}},
+		{Message: "c", Fields: []log.RecordField{{"duration", 2 * time.Second}, {"outcome", "failure"}, {"error", "failed"}}},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+func TestStart(t *testing.T) {
+	now := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+	var r log.Recorder
+	ctx := xtime.Using(log.Using(context.Background(), &r), xtime.ClockFunc(func() time.Time { return now }))
+	ctx, done := log.Start(ctx, "a", log.Int("b", 1))
+	_, nested := log.Start(ctx, "c")
+	now = now.Add(time.Second)
+	nested(nil)
+	done(nil)
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Name: "a", Message: "started", Fields: []log.RecordField{{"b", 1}}},
+		{Name: "a.c", Message: "started"},
+		{Name: "a.c", Message: "finished", Fields: []log.RecordField{{"duration", time.Second}, {"outcome", "success"}}},
+		{Name: "a", Message: "finished", Fields: []log.RecordField{{"b", 1}, {"duration", time.Second}, {"outcome", "success"}}},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+func TestTimedStopped(t *testing.T) {
+	var r log.Recorder
+	ctx := xtime.Stopped(log.Using(context.Background(), &r), time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC))
+	log.Timed(ctx, "a")(nil)
+	if v, _ := r.Entries()[0].Value("duration"); v != time.Duration(0) {
+		t.Errorf(`r.Entries()[0].Value("duration") = %v, want 0s`, v)
+	}
+}
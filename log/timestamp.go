@@ -0,0 +1,56 @@
+package log
+
+import (
+	"context"
+
+	"github.com/now/x/log/value"
+	xtime "github.com/now/x/time"
+)
+
+// Timestamped is a Logger that adds a Field “time” with the time of each entry,
+// as determined by clock.Now(), before the Fields of the entry, and then
+// delegates to l.
+//
+// The time is a value.Time, if layout is empty, and a value.String of the time
+// formatted by layout, otherwise.
+func Timestamped(l Logger, clock xtime.Clock, layout string) Logger {
+	return timestampLogger{l, clock, layout}
+}
+
+// Timestamping is ctxʹ ≈ ctx such that In(ctxʹ) = Timestamped(l, c, layout),
+// where l = In(ctx) and c is the Clock of ctx.
+//
+// This uses the Clock of ctx at the time of the call, so, for example,
+// xtime.Stopped(ctx, t) makes the timestamps of the entries reproducible.
+func Timestamping(ctx context.Context, layout string) context.Context {
+	return Using(ctx, Timestamped(in(ctx), ctx.Value(xtime.Key).(xtime.Clock), layout))
+}
+
+type timestampLogger struct {
+	logger Logger
+	clock  xtime.Clock
+	layout string
+}
+
+func (t timestampLogger) Entry(message string, fields ...Field) error {
+	now := t.clock.Now()
+	var v Value = value.Time{Value: now}
+	if t.layout != "" {
+		v = value.String(now.Format(t.layout))
+	}
+	return t.logger.Entry(message, append([]Field{{"time", v}}, fields...)...)
+}
+
+func (t timestampLogger) Named(name string) Logger {
+	if name == "" {
+		return t
+	}
+	return timestampLogger{t.logger.Named(name), t.clock, t.layout}
+}
+
+func (t timestampLogger) With(fields ...Field) Logger {
+	if len(fields) == 0 {
+		return t
+	}
+	return timestampLogger{t.logger.With(fields...), t.clock, t.layout}
+}
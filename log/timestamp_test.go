@@ -0,0 +1,38 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log"
+	xtime "github.com/now/x/time"
+)
+
+func TestTimestamped(t *testing.T) {
+	at := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+	var r log.Recorder
+	l := log.Timestamped(&r, xtime.ClockFunc(func() time.Time { return at }), "")
+	l.Named("a").With(log.Int("b", 1)).Entry("c", log.Int("d", 2))
+	log.Timestamped(&r, xtime.ClockFunc(func() time.Time { return at }), time.Kitchen).Entry("e")
+	if diff := cmp.Diff(r.Entries(), []log.Record{
+		{Name: "a", Message: "c", Fields: []log.RecordField{{"b", 1}, {"time", at}, {"d", 2}}},
+		{Message: "e", Fields: []log.RecordField{{"time", "7:51PM"}}},
+	}); diff != "" {
+		t.Errorf("r.Entries() diff -got +want\n%s", diff)
+	}
+}
+
+func TestTimestamping(t *testing.T) {
+	var b bytes.Buffer
+	ctx := log.Using(context.Background(), log.JSON(&b))
+	ctx = xtime.Stopped(ctx, time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC))
+	ctx = log.Timestamping(ctx, time.RFC3339)
+	log.Entry(ctx, "a")
+	if got, want := b.String(), `{"message":"a","time":"2022-03-09T19:51:00Z"}`+"\n"; got != want {
+		t.Errorf("log.Entry(…) wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,19 @@
+package value
+
+// Array log.Value that’ll write its elements in a nested scope.
+type Array []Value
+
+// Write w.Array(…), if w is an ArrayWriter, writing each element of a within
+// it.  Otherwise, each element of a is written to w in turn.
+//
+// Errors if an element errors.
+func (a Array) Write(w Writer) error {
+	if aw, ok := w.(ArrayWriter); ok {
+		return aw.Array(a.write)
+	}
+	return a.write(w)
+}
+
+func (a Array) write(w Writer) error {
+	return writeValues(w, a)
+}
@@ -0,0 +1,28 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestArrayWrite(t *testing.T) {
+	tests := []struct {
+		a    value.Array
+		want string
+	}{
+		{value.Array{}, "[]"},
+		{value.Array{value.Int(1), value.Int64(2), value.String("c")}, "[1, 2, c]"},
+		{value.Array{value.Array{value.Int(1)}, value.Object{field{"a", value.Int(2)}}}, "[[1], {a: 2}]"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Array(%#v).Write(…)", tt.a)
+		var w value.BytesWriter
+		if err := tt.a.Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package value
+
+// Bool log.Value that’ll write itself.
+type Bool bool
+
+// Write w.Bool(bool(b)), if w is a BoolWriter, w.Reflect(bool(b)), otherwise.
+func (b Bool) Write(w Writer) error {
+	if bw, ok := w.(BoolWriter); ok {
+		return bw.Bool(bool(b))
+	}
+	return w.Reflect(bool(b))
+}
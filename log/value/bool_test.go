@@ -0,0 +1,27 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestBoolWrite(t *testing.T) {
+	tests := []struct {
+		b    bool
+		want string
+	}{
+		{true, "true"},
+		{false, "false"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Bool(%#v).Write(…)", tt.b)
+		var w value.BytesWriter
+		if err := value.Bool(tt.b).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
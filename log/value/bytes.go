@@ -0,0 +1,13 @@
+package value
+
+// Bytes log.Value that’ll write itself as binary data.
+type Bytes []byte
+
+// Write w.Binary([]byte(b)), if w is a BinaryWriter, w.Reflect([]byte(b)),
+// otherwise.
+func (b Bytes) Write(w Writer) error {
+	if bw, ok := w.(BinaryWriter); ok {
+		return bw.Binary([]byte(b))
+	}
+	return w.Reflect([]byte(b))
+}
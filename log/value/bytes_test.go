@@ -0,0 +1,26 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestBytesWrite(t *testing.T) {
+	tests := []struct {
+		b    []byte
+		want string
+	}{
+		{[]byte("abc"), "YWJj"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Bytes(%#v).Write(…)", tt.b)
+		var w value.BytesWriter
+		if err := value.Bytes(tt.b).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
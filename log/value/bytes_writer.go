@@ -1,8 +1,10 @@
 package value
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strconv"
+	"time"
 )
 
 type BytesWriter struct {
@@ -15,10 +17,37 @@ func (w *BytesWriter) Int(i int) error {
 }
 
 func (w *BytesWriter) Int64(i int64) error {
+	w.separator()
 	w.Bytes = strconv.AppendInt(w.Bytes, i, 10)
 	return nil
 }
 
+func (w *BytesWriter) Bool(b bool) error {
+	w.separator()
+	w.Bytes = strconv.AppendBool(w.Bytes, b)
+	return nil
+}
+
+func (w *BytesWriter) Float64(f float64) error {
+	w.separator()
+	w.Bytes = strconv.AppendFloat(w.Bytes, f, 'g', -1, 64)
+	return nil
+}
+
+func (w *BytesWriter) Duration(d time.Duration) error {
+	return w.String(d.String())
+}
+
+func (w *BytesWriter) Time(t time.Time) error {
+	w.separator()
+	w.Bytes = t.AppendFormat(w.Bytes, time.RFC3339Nano)
+	return nil
+}
+
+func (w *BytesWriter) Binary(b []byte) error {
+	return w.String(base64.StdEncoding.EncodeToString(b))
+}
+
 func (w *BytesWriter) Reflect(r interface{}) error {
 	return w.String(fmt.Sprintf("%+v", r))
 }
@@ -29,6 +58,14 @@ func (w *BytesWriter) String(s string) error {
 	return nil
 }
 
+func (w *BytesWriter) Object(f func(Writer) error) error {
+	return w.nested('{', '}', f)
+}
+
+func (w *BytesWriter) Array(f func(Writer) error) error {
+	return w.nested('[', ']', f)
+}
+
 func (w *BytesWriter) Field(key string, f func(Writer) error) error {
 	if w.separate {
 		w.bytes([]byte("; "))
@@ -39,6 +76,16 @@ func (w *BytesWriter) Field(key string, f func(Writer) error) error {
 	return f(w)
 }
 
+func (w *BytesWriter) nested(open, close byte, f func(Writer) error) error {
+	w.separator()
+	w.Bytes = append(w.Bytes, open)
+	w.separate = false
+	err := f(w)
+	w.Bytes = append(w.Bytes, close)
+	w.separate = true
+	return err
+}
+
 func (w *BytesWriter) separator() {
 	if w.separate {
 		w.bytes([]byte(", "))
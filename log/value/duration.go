@@ -0,0 +1,15 @@
+package value
+
+import "time"
+
+// Duration log.Value that’ll write itself.
+type Duration time.Duration
+
+// Write w.Duration(time.Duration(d)), if w is a DurationWriter,
+// w.Reflect(time.Duration(d)), otherwise.
+func (d Duration) Write(w Writer) error {
+	if dw, ok := w.(DurationWriter); ok {
+		return dw.Duration(time.Duration(d))
+	}
+	return w.Reflect(time.Duration(d))
+}
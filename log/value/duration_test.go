@@ -0,0 +1,27 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+func TestDurationWrite(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{1500 * time.Millisecond, "1.5s"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Duration(%#v).Write(…)", tt.d)
+		var w value.BytesWriter
+		if err := value.Duration(tt.d).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,33 @@
 package value
 
-// Error log.Value that’ll write Err.Error().
+import "fmt"
+
+// Error log.Value that’ll write Err.
+//
+// If Err is a Valuer, Err.LogValue() is written.  Otherwise, if Err doesn’t
+// wrap any other errors and isn’t a FieldsError, Err.Error() is written as a
+// String.  Otherwise, Err is written as an Object consisting of Fields
+// “message”, Err.Error() as a String, and “type”, the concrete type of Err as
+// formatted by fmt.Sprintf("%T", Err), followed by the Fields of
+// Err.LogFields(), if Err is a FieldsError, followed by “causes”, an Array of
+// the errors that Err wraps, if any, each written as such an Object in turn.
+//
+// The errors that Err wraps are those returned by an Unwrap() error or an
+// Unwrap() []error method, as used by errors.Unwrap, errors.Is, and errors.As.
 type Error struct {
 	Err error
 }
 
-// Write w.String(e.Err.Error()).
+// FieldsError is implemented by errors that carry Fields to write along with
+// them.
+type FieldsError interface {
+	error
+
+	// LogFields to write along with the error, typically log.Fields.
+	LogFields() Object
+}
+
+// Write e.Err to w as described by Error.
 //
 // Any panic caused by e.Err.Error() is caught.  A panic caused by e.Err being
 // nil will result in w.String("<nil>") instead.  Errors on any other panic.
@@ -16,6 +38,47 @@ func (e Error) Write(w Writer) (err error) {
 			err = panicNilCheck(rerr, e.Err, w)
 		}
 	}()
-	w.String(e.Err.Error())
-	return
+	if v, ok := e.Err.(Valuer); ok {
+		return v.LogValue().Write(w)
+	}
+	if _, ok := e.Err.(FieldsError); !ok && len(causes(e.Err)) == 0 {
+		return w.String(e.Err.Error())
+	}
+	return errorObject(e.Err, 0).Write(w)
+}
+
+// errorObject is err as an Object, as described by Error.
+func errorObject(err error, depth int) Object {
+	o := Object{
+		field{"message", String(err.Error())},
+		field{"type", String(fmt.Sprintf("%T", err))},
+	}
+	if fe, ok := err.(FieldsError); ok {
+		o = append(o, fe.LogFields()...)
+	}
+	if cs := causes(err); len(cs) > 0 && depth < maxStructDepth {
+		a := make(Array, len(cs))
+		for i, c := range cs {
+			if c == nil {
+				a[i] = String("<nil>")
+			} else {
+				a[i] = errorObject(c, depth+1)
+			}
+		}
+		o = append(o, field{"causes", a})
+	}
+	return o
+}
+
+// causes of err, that is, the errors that it wraps.
+func causes(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if c := u.Unwrap(); c != nil {
+			return []error{c}
+		}
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	}
+	return nil
 }
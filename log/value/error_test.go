@@ -1,6 +1,7 @@
 package value_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -38,3 +39,76 @@ func TestErrorWrite(t *testing.T) {
 		}
 	}
 }
+
+type fieldsError struct {
+	err error
+}
+
+func (e fieldsError) Error() string {
+	return "fielded"
+}
+
+func (e fieldsError) Unwrap() error {
+	return e.err
+}
+
+func (fieldsError) LogFields() value.Object {
+	return value.Object{field{"status", value.Int(404)}}
+}
+
+type joinedError []error
+
+func (e joinedError) Error() string {
+	return "joined"
+}
+
+func (e joinedError) Unwrap() []error {
+	return e
+}
+
+type valuerError struct{}
+
+func (valuerError) Error() string {
+	return "valued"
+}
+
+func (valuerError) LogValue() value.Value {
+	return value.Int(1)
+}
+
+func TestErrorWriteChains(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{
+			fmt.Errorf("b: %w", errors.New("a")),
+			"{message: b: a; type: *fmt.wrapError; causes: [{message: a; type: *errors.errorString}]}",
+		},
+		{
+			joinedError{errors.New("a"), fmt.Errorf("c: %w", errors.New("b"))},
+			"{message: joined; type: value_test.joinedError; causes: [{message: a; type: *errors.errorString}, {message: c: b; type: *fmt.wrapError; causes: [{message: b; type: *errors.errorString}]}]}",
+		},
+		{
+			fieldsError{},
+			"{message: fielded; type: value_test.fieldsError; status: 404}",
+		},
+		{
+			fmt.Errorf("b: %w", fieldsError{errors.New("a")}),
+			"{message: b: fielded; type: *fmt.wrapError; causes: [{message: fielded; type: value_test.fieldsError; status: 404; causes: [{message: a; type: *errors.errorString}]}]}",
+		},
+		{
+			valuerError{},
+			"1",
+		},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Error{Err: %#v}.Write(…)", tt.err)
+		var w value.BytesWriter
+		if err := (value.Error{Err: tt.err}.Write(&w)); err != nil {
+			t.Errorf("%s = %v, want nil", expression, err)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package value
+
+// Float64 log.Value that’ll write itself.
+type Float64 float64
+
+// Write w.Float64(float64(f)), if w is a Float64Writer, w.Reflect(float64(f)),
+// otherwise.
+func (f Float64) Write(w Writer) error {
+	if fw, ok := w.(Float64Writer); ok {
+		return fw.Float64(float64(f))
+	}
+	return w.Reflect(float64(f))
+}
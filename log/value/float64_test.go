@@ -0,0 +1,27 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestFloat64Write(t *testing.T) {
+	tests := []struct {
+		f    float64
+		want string
+	}{
+		{0.5, "0.5"},
+		{1e21, "1e+21"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Float64(%#v).Write(…)", tt.f)
+		var w value.BytesWriter
+		if err := value.Float64(tt.f).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package value
+
+import (
+	"runtime"
+	"strconv"
+)
+
+// Frame log.Value of a stack frame.
+type Frame struct {
+	Function string // Function is the package path-qualified function name.
+	File     string // File is the path of the file containing the function.
+	Line     int    // Line is the line number in File.
+}
+
+// Write f as an Object consisting of Fields “function”, “file”, and “line”.
+func (f Frame) Write(w Writer) error {
+	return Object{
+		field{"function", String(f.Function)},
+		field{"file", String(f.File)},
+		field{"line", Int(f.Line)},
+	}.Write(w)
+}
+
+// String is f.File, a colon, U+003A, and f.Line.
+func (f Frame) String() string {
+	return f.File + ":" + strconv.Itoa(f.Line)
+}
+
+// Caller log.Value of the Frame where a log entry was made.
+//
+// This is a separate type from Frame so that Loggers can treat it specially.
+type Caller Frame
+
+// Write Frame(c).
+func (c Caller) Write(w Writer) error {
+	return Frame(c).Write(w)
+}
+
+// Stack log.Value of a goroutine’s stack, innermost Frame first.
+type Stack []Frame
+
+// Write s as an Array of its Frames.
+func (s Stack) Write(w Writer) error {
+	a := make(Array, len(s))
+	for i, f := range s {
+		a[i] = f
+	}
+	return a.Write(w)
+}
+
+// Frames of runtime.Frames.
+func Frames(frames *runtime.Frames) Stack {
+	var s Stack
+	for {
+		f, more := frames.Next()
+		if f.PC != 0 {
+			s = append(s, Frame{f.Function, f.File, f.Line})
+		}
+		if !more {
+			return s
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package value_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestFrameWrite(t *testing.T) {
+	tests := []struct {
+		v    value.Value
+		want string
+	}{
+		{value.Frame{"f", "a.go", 1}, "{function: f; file: a.go; line: 1}"},
+		{value.Caller{"f", "a.go", 1}, "{function: f; file: a.go; line: 1}"},
+		{value.Stack{{"f", "a.go", 1}, {"g", "b.go", 2}}, "[{function: f; file: a.go; line: 1}, {function: g; file: b.go; line: 2}]"},
+		{value.Stack{}, "[]"},
+	}
+	for _, tt := range tests {
+		var w value.BytesWriter
+		if err := tt.v.Write(&w); err != nil {
+			t.Errorf("%#v.Write(…) = %v, want nil", tt.v, err)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%#v.Write(…) = %#v, want %#v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	if got, want := (value.Frame{"f", "a.go", 12}).String(), "a.go:12"; got != want {
+		t.Errorf("value.Frame{…}.String() = %#v, want %#v", got, want)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	pcs := make([]uintptr, 1)
+	n := runtime.Callers(1, pcs)
+	s := value.Frames(runtime.CallersFrames(pcs[:n]))
+	if len(s) != 1 || !strings.HasSuffix(s[0].Function, ".TestFrames") || !strings.HasSuffix(s[0].File, "frame_test.go") {
+		t.Errorf("value.Frames(…) = %#v, want frame of TestFrames", s)
+	}
+	if s := value.Frames(runtime.CallersFrames(nil)); len(s) != 0 {
+		t.Errorf("value.Frames(runtime.CallersFrames(nil)) = %#v, want empty", s)
+	}
+}
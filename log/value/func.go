@@ -0,0 +1,12 @@
+package value
+
+// Func log.Value that’ll write itself by being called.
+//
+// This allows for deferring work, such as computing an expensive value, until
+// a Logger actually writes the Func, which it might not do at all.
+type Func func(Writer) error
+
+// Write f(w).
+func (f Func) Write(w Writer) error {
+	return f(w)
+}
@@ -0,0 +1,19 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+func TestFuncWrite(t *testing.T) {
+	var w value.BytesWriter
+	f := value.Func(func(w value.Writer) error {
+		return w.String("abc")
+	})
+	if err := f.Write(&w); err != nil {
+		t.Errorf("value.Func(…).Write(…) = %v, want nil", err)
+	} else if got, want := string(w.Bytes), "abc"; got != want {
+		t.Errorf("value.Func(…).Write(…) = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package value
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONWriter of values as JSON to Bytes.
+//
+// Bools are written as JSON booleans.  Float64s, Ints, and Int64s are written
+// as JSON numbers, except for infinities and NaNs, which are written as the
+// JSON strings "+Inf", "-Inf", and "NaN".  Durations are written as JSON
+// strings formatted by time.Duration.String, Times as JSON strings in the
+// time.RFC3339Nano layout, Bytes as JSON strings of their standard base64
+// encoding, Strings as JSON strings, and Reflects as whatever encoding/json
+// marshals them as.  A Field is written as an object member, that is, its label
+// as a JSON string, a colon, U+003A, and its value.  Objects and Arrays are
+// written as JSON objects and arrays.  Consecutive values and members are
+// separated by a comma, U+002C.
+//
+// So that what’s written is always valid JSON, a Field written as an element of
+// an Array or as the value of another Field is written as a JSON object of a
+// single member, more than one value written as the value of a Field is
+// written as a JSON array of those values, and a Field whose value writes
+// nothing is written with the value null.
+//
+// The braces surrounding members are left to the user of the JSONWriter, so
+// that additional members may be added before or after any Fields.
+type JSONWriter struct {
+	Bytes    []byte
+	separate bool
+
+	// scope is what’s being written: the members of an object, the elements of
+	// an array, or the value of a Field, which began at start in Bytes and of
+	// which values have been written so far.
+	scope  jsonScope
+	start  int
+	values int
+}
+
+type jsonScope int
+
+const (
+	jsonMembers jsonScope = iota
+	jsonElements
+	jsonValue
+)
+
+// Bool writes b as a JSON boolean.
+func (w *JSONWriter) Bool(b bool) error {
+	w.value()
+	w.Bytes = strconv.AppendBool(w.Bytes, b)
+	return nil
+}
+
+// Float64 writes f as a JSON number, if it’s finite, a JSON string, otherwise.
+func (w *JSONWriter) Float64(f float64) error {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return w.String(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	w.value()
+	w.Bytes = strconv.AppendFloat(w.Bytes, f, 'g', -1, 64)
+	return nil
+}
+
+// Duration writes d.String() as a JSON string.
+func (w *JSONWriter) Duration(d time.Duration) error {
+	return w.String(d.String())
+}
+
+// Time writes t in the time.RFC3339Nano layout as a JSON string.
+func (w *JSONWriter) Time(t time.Time) error {
+	return w.String(t.Format(time.RFC3339Nano))
+}
+
+// Binary writes the standard base64 encoding of b as a JSON string.
+func (w *JSONWriter) Binary(b []byte) error {
+	return w.String(base64.StdEncoding.EncodeToString(b))
+}
+
+// Int writes i as a JSON number.
+func (w *JSONWriter) Int(i int) error {
+	return w.Int64(int64(i))
+}
+
+// Int64 writes i as a JSON number.
+func (w *JSONWriter) Int64(i int64) error {
+	w.value()
+	w.Bytes = strconv.AppendInt(w.Bytes, i, 10)
+	return nil
+}
+
+// Reflect writes r as marshaled by encoding/json, without escaping HTML.
+//
+// Errors if r can’t be marshaled.
+func (w *JSONWriter) Reflect(r interface{}) error {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(r); err != nil {
+		return err
+	}
+	w.value()
+	w.Bytes = append(w.Bytes, bytes.TrimSuffix(b.Bytes(), []byte{'\n'})...)
+	return nil
+}
+
+// String writes s as a JSON string.
+func (w *JSONWriter) String(s string) error {
+	w.value()
+	w.string(s)
+	return nil
+}
+
+// Object writes a JSON object, calling f(w) to write its members.
+func (w *JSONWriter) Object(f func(Writer) error) error {
+	return w.nested('{', '}', f)
+}
+
+// Array writes a JSON array, calling f(w) to write its elements.
+func (w *JSONWriter) Array(f func(Writer) error) error {
+	return w.nested('[', ']', f)
+}
+
+// Field writes label as a JSON string, a colon, and then calls f(w), wrapped
+// in a JSON object, if it’s written as an element of an Array or as the value
+// of another Field.  If f(w) writes more than one value, they’re wrapped in a
+// JSON array, and if it writes nothing, null is written.
+func (w *JSONWriter) Field(label string, f func(Writer) error) error {
+	if w.scope != jsonMembers {
+		return w.Object(func(Writer) error {
+			return w.Field(label, f)
+		})
+	}
+	w.separator()
+	w.string(label)
+	w.Bytes = append(w.Bytes, ':')
+	scope, start, values := w.scope, w.start, w.values
+	w.scope, w.start, w.values = jsonValue, len(w.Bytes), 0
+	err := f(w)
+	if w.values > 1 {
+		w.Bytes = append(w.Bytes, ']')
+	} else if len(w.Bytes) == w.start {
+		w.Bytes = append(w.Bytes, "null"...)
+	}
+	w.scope, w.start, w.values = scope, start, values
+	w.separate = true
+	return err
+}
+
+func (w *JSONWriter) nested(open, close byte, f func(Writer) error) error {
+	w.value()
+	w.Bytes = append(w.Bytes, open)
+	scope := w.scope
+	if open == '{' {
+		w.scope = jsonMembers
+	} else {
+		w.scope = jsonElements
+	}
+	start, values := w.start, w.values
+	w.separate = false
+	err := f(w)
+	w.scope, w.start, w.values = scope, start, values
+	w.Bytes = append(w.Bytes, close)
+	w.separate = true
+	return err
+}
+
+// value precedes a value with a separator, if needed, wrapping the values of
+// a Field in a JSON array once a second one is written.
+func (w *JSONWriter) value() {
+	if w.scope != jsonValue {
+		w.separator()
+		return
+	}
+	w.values++
+	switch {
+	case w.values == 2:
+		w.Bytes = append(w.Bytes, 0)
+		copy(w.Bytes[w.start+1:], w.Bytes[w.start:])
+		w.Bytes[w.start] = '['
+		fallthrough
+	case w.values > 2:
+		w.Bytes = append(w.Bytes, ',')
+	}
+}
+
+func (w *JSONWriter) separator() {
+	if w.separate {
+		w.Bytes = append(w.Bytes, ',')
+	} else {
+		w.separate = true
+	}
+}
+
+const hex = "0123456789abcdef"
+
+func (w *JSONWriter) string(s string) {
+	w.Bytes = append(w.Bytes, '"')
+	var i int
+	for j := 0; j < len(s); {
+		if c := s[j]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				j++
+				continue
+			}
+			w.Bytes = append(w.Bytes, s[i:j]...)
+			switch c {
+			case '"', '\\':
+				w.Bytes = append(w.Bytes, '\\', c)
+			case '\n':
+				w.Bytes = append(w.Bytes, '\\', 'n')
+			case '\r':
+				w.Bytes = append(w.Bytes, '\\', 'r')
+			case '\t':
+				w.Bytes = append(w.Bytes, '\\', 't')
+			default:
+				w.Bytes = append(w.Bytes, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			}
+			j++
+			i = j
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[j:])
+		if r == utf8.RuneError && n == 1 {
+			w.Bytes = append(w.Bytes, s[i:j]...)
+			w.Bytes = append(w.Bytes, "\ufffd"...)
+			j += n
+			i = j
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			w.Bytes = append(w.Bytes, s[i:j]...)
+			w.Bytes = append(w.Bytes, '\\', 'u', '2', '0', '2', hex[r&0xf])
+			j += n
+			i = j
+			continue
+		}
+		j += n
+	}
+	w.Bytes = append(w.Bytes, s[i:]...)
+	w.Bytes = append(w.Bytes, '"')
+}
@@ -0,0 +1,201 @@
+package value
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LogfmtWriter of values in logfmt to Bytes.
+//
+// A Field is written as a key-value pair, that is, its label, an equals sign,
+// U+003D, and its value.  Pairs are separated from anything preceding them in
+// Bytes by a space, U+0020.  Any rune in the label that isn’t allowed in a
+// logfmt key, that is, space, equals sign, quotation mark, U+0022, or any
+// rune that isn’t a graphic, is replaced by low line, U+005F.
+//
+// As logfmt has no notion of nesting, the Fields of an Object, and any Fields
+// written as, or among, the values of a Field, such as the elements of an
+// Array, are written as pairs with their labels prefixed by the label of the
+// enclosing Field and a period, U+002E.  The elements of an Array are written
+// as consecutive values, and any values following such a pair are written as a
+// new pair with the label of the enclosing Field.
+//
+// Bools are written as true or false.  Float64s are written as by
+// strconv.FormatFloat(f, 'g', -1, 64).  Durations are written as by
+// time.Duration.String, Times in the time.RFC3339Nano layout, and Bytes as
+// their standard base64 encoding.  Ints and Int64s are written in base ten.
+// Strings are written as is, unless they are empty or contain space, equals
+// sign, quotation mark, comma, U+002C, or any rune that isn’t graphic, in which
+// case they are quoted as by strconv.Quote.  Reflects r are written as Strings
+// fmt.Sprintf("%+v", r).  Consecutive values are separated by a comma, U+002C.
+type LogfmtWriter struct {
+	Bytes    []byte
+	prefix   string
+	key      string
+	inField  bool // inField is true while writing the value of a Field.
+	pending  bool
+	separate bool
+}
+
+// Bool writes b as true or false.
+func (w *LogfmtWriter) Bool(b bool) error {
+	w.separator()
+	w.Bytes = strconv.AppendBool(w.Bytes, b)
+	return nil
+}
+
+// Float64 writes f as by strconv.FormatFloat(f, 'g', -1, 64).
+func (w *LogfmtWriter) Float64(f float64) error {
+	w.separator()
+	w.Bytes = strconv.AppendFloat(w.Bytes, f, 'g', -1, 64)
+	return nil
+}
+
+// Duration writes d.String().
+func (w *LogfmtWriter) Duration(d time.Duration) error {
+	return w.String(d.String())
+}
+
+// Time writes t in the time.RFC3339Nano layout.
+func (w *LogfmtWriter) Time(t time.Time) error {
+	return w.String(t.Format(time.RFC3339Nano))
+}
+
+// Binary writes the standard base64 encoding of b.
+func (w *LogfmtWriter) Binary(b []byte) error {
+	return w.String(base64.StdEncoding.EncodeToString(b))
+}
+
+// Int writes i in base ten.
+func (w *LogfmtWriter) Int(i int) error {
+	return w.Int64(int64(i))
+}
+
+// Int64 writes i in base ten.
+func (w *LogfmtWriter) Int64(i int64) error {
+	w.separator()
+	w.Bytes = strconv.AppendInt(w.Bytes, i, 10)
+	return nil
+}
+
+// Reflect writes w.String(fmt.Sprintf("%+v", r)).
+func (w *LogfmtWriter) Reflect(r interface{}) error {
+	return w.String(fmt.Sprintf("%+v", r))
+}
+
+// String writes s, quoting it if necessary.
+func (w *LogfmtWriter) String(s string) error {
+	w.separator()
+	if needsQuoting(s) {
+		w.Bytes = strconv.AppendQuote(w.Bytes, s)
+	} else {
+		w.Bytes = append(w.Bytes, s...)
+	}
+	return nil
+}
+
+// Object calls f(w), prefixing the labels of any Fields it writes by the
+// label of the enclosing Field, if any, and a period, U+002E.
+//
+// If f(w) doesn’t write anything, the enclosing Field is written with an empty
+// value.
+func (w *LogfmtWriter) Object(f func(Writer) error) error {
+	prefix, inField, pending, separate, n := w.prefix, w.inField, w.pending, w.separate, len(w.Bytes)
+	if w.key != "" {
+		w.prefix = w.key + "."
+	}
+	w.inField, w.pending = false, false
+	err := f(w)
+	w.prefix, w.inField = prefix, inField
+	w.restore(pending, separate, n)
+	return err
+}
+
+// Array calls f(w), separating any values it writes by commas and prefixing
+// the labels of any Fields it writes like Object.
+func (w *LogfmtWriter) Array(f func(Writer) error) error {
+	return f(w)
+}
+
+// Field writes label as a key, an equals sign, and then calls f(w).
+//
+// The key and the equals sign are written once f(w) writes a value, so that
+// any Object or Field it writes may prefix the labels of its Fields instead.
+// If f(w) doesn’t write anything, the Field is written with an empty value.
+func (w *LogfmtWriter) Field(label string, f func(Writer) error) error {
+	key, inField, pending, separate, n := w.key, w.inField, w.pending, w.separate, len(w.Bytes)
+	if w.inField {
+		w.key = w.key + "." + label
+	} else {
+		w.key = w.prefix + label
+	}
+	w.inField, w.pending, w.separate = true, true, false
+	err := f(w)
+	if len(w.Bytes) == n {
+		w.pair()
+	}
+	w.key, w.inField = key, inField
+	w.restore(pending, separate, n)
+	return err
+}
+
+// restore pending and separate after writing a nested Object or Field that
+// began at w.Bytes[n], unless it wrote pairs in the value of a Field, in which
+// case any further values of the Field are written as a new pair.
+func (w *LogfmtWriter) restore(pending, separate bool, n int) {
+	if w.inField && len(w.Bytes) > n {
+		w.pending, w.separate = true, false
+	} else {
+		w.pending, w.separate = pending, separate
+	}
+}
+
+func (w *LogfmtWriter) pair() {
+	if len(w.Bytes) > 0 {
+		w.Bytes = append(w.Bytes, ' ')
+	}
+	if w.key == "" {
+		w.Bytes = append(w.Bytes, '_')
+	}
+	for _, r := range w.key {
+		if isKeyRune(r) {
+			w.Bytes = append(w.Bytes, string(r)...)
+		} else {
+			w.Bytes = append(w.Bytes, '_')
+		}
+	}
+	w.Bytes = append(w.Bytes, '=')
+	w.pending = false
+}
+
+func (w *LogfmtWriter) separator() {
+	switch {
+	case w.pending:
+		w.pair()
+		w.separate = true
+	case w.separate:
+		w.Bytes = append(w.Bytes, ',')
+	default:
+		w.separate = true
+	}
+}
+
+func isKeyRune(r rune) bool {
+	return r != ' ' && r != '=' && r != '"' && r != utf8.RuneError && unicode.IsGraphic(r)
+}
+
+func needsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if !isKeyRune(r) || r == ',' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package value
+
+// Object log.Value that’ll write its elements in a nested scope.
+//
+// The elements are typically log.Fields, so that the Object is written as a
+// set of labeled values.
+type Object []Value
+
+// Write w.Object(…), if w is an ObjectWriter, writing each element of o within
+// it.  Otherwise, each element of o is written to w in turn.
+//
+// Errors if an element errors.
+func (o Object) Write(w Writer) error {
+	if ow, ok := w.(ObjectWriter); ok {
+		return ow.Object(o.write)
+	}
+	return o.write(w)
+}
+
+func (o Object) write(w Writer) error {
+	return writeValues(w, o)
+}
+
+func writeValues(w Writer, values []Value) error {
+	for _, v := range values {
+		if err := v.Write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
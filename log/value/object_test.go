@@ -0,0 +1,37 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/now/x/log/value"
+)
+
+type field struct {
+	label string
+	value value.Value
+}
+
+func (f field) Write(w value.Writer) error {
+	return w.Field(f.label, f.value.Write)
+}
+
+func TestObjectWrite(t *testing.T) {
+	tests := []struct {
+		o    value.Object
+		want string
+	}{
+		{value.Object{}, "{}"},
+		{value.Object{field{"a", value.Int(1)}, field{"b", value.String("c")}}, "{a: 1; b: c}"},
+		{value.Object{field{"a", value.Object{field{"b", value.Int(1)}}}}, "{a: {b: 1}}"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Object(%#v).Write(…)", tt.o)
+		var w value.BytesWriter
+		if err := tt.o.Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Struct log.Value that’ll write the exported fields of Value as an Object.
+//
+// Each exported field of Value is written as a Field labeled by the field’s
+// name.  This can be controlled by a “log” key in the field’s tag, consisting
+// of a name, optionally followed by comma-separated options:
+//
+//	// Field is written with label “name”.
+//	Field int `log:"name"`
+//
+//	// Field is written with label “Field” unless it’s the zero value.
+//	Field int `log:",omitempty"`
+//
+//	// Field is written as Redacted{}.
+//	Field string `log:",redact"`
+//
+//	// Field is skipped.
+//	Field int `log:"-"`
+//
+// The fields of an embedded struct without a name in its tag are written as if
+// they were fields of Value.
+//
+// The value of a field f is written as f.LogValue(), if f is a Valuer, a Bool,
+// Bytes, Duration, Error, Float64, Int64, String, or Time, if f is of a
+// corresponding kind or type, an Array, if f is an array or slice, a Struct,
+// if f is a struct, and a Reflect, otherwise.  Pointers and interfaces are
+// followed, with nil being written as String("<nil>").
+//
+// If Value itself is a Valuer, Value.LogValue() is written instead.  If Value,
+// after following any pointers, isn’t a struct, it’s written as described for
+// fields above.
+type Struct struct {
+	Value interface{}
+}
+
+// Valuer is implemented by types that control how they’re written by Struct
+// and Error.
+type Valuer interface {
+	// LogValue is the Value to write in place of the receiver.
+	LogValue() Value
+}
+
+// Redacted log.Value that’ll write RedactedPlaceholder in place of a value that
+// mustn’t be logged.
+type Redacted struct{}
+
+// RedactedPlaceholder is what Redacted writes.
+const RedactedPlaceholder = "[REDACTED]"
+
+// Write w.String(RedactedPlaceholder).
+func (Redacted) Write(w Writer) error {
+	return w.String(RedactedPlaceholder)
+}
+
+// Write s.Value as described by Struct.
+func (s Struct) Write(w Writer) error {
+	return reflectValue(reflect.ValueOf(s.Value), 0).Write(w)
+}
+
+// maxStructDepth limits how deeply nested values are followed, including
+// through pointers, interfaces, and Valuers, which guards against cyclic data
+// structures.
+const maxStructDepth = 16
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	timeType     = reflect.TypeOf(time.Time{})
+)
+
+func reflectValue(v reflect.Value, depth int) Value {
+	if !v.IsValid() {
+		return String("<nil>")
+	}
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return String("<nil>")
+	}
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case Valuer:
+			if depth >= maxStructDepth {
+				break
+			}
+			// Follow a Struct at the next depth, so that a Valuer that returns
+			// a Struct of itself doesn’t recurse forever.
+			lv := i.LogValue()
+			if s, ok := lv.(Struct); ok {
+				return reflectValue(reflect.ValueOf(s.Value), depth+1)
+			}
+			return lv
+		case error:
+			return Error{Err: i}
+		case time.Time:
+			return Time{Value: i}
+		}
+	}
+	if depth >= maxStructDepth {
+		return reflectInterface(v)
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return reflectValue(v.Elem(), depth+1)
+	case reflect.Bool:
+		return Bool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == durationType {
+			return Duration(v.Int())
+		}
+		return Int64(v.Int())
+	case reflect.Float32, reflect.Float64:
+		return Float64(v.Float())
+	case reflect.String:
+		return String(v.String())
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return Bytes(v.Bytes())
+		}
+		fallthrough
+	case reflect.Array:
+		a := make(Array, v.Len())
+		for i := range a {
+			a[i] = reflectValue(v.Index(i), depth+1)
+		}
+		return a
+	case reflect.Struct:
+		return structFields(v, depth, Object{})
+	default:
+		return reflectInterface(v)
+	}
+}
+
+// reflectInterface is Reflect{Value: v.Interface()}, unless v was obtained
+// through an unexported field, in which case it’s a String of v formatted by
+// fmt.Sprintf("%+v", v).
+func reflectInterface(v reflect.Value) Value {
+	if v.CanInterface() {
+		return Reflect{Value: v.Interface()}
+	}
+	return String(fmt.Sprintf("%+v", v))
+}
+
+func structFields(v reflect.Value, depth int, o Object) Object {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, options := parseTag(sf.Tag.Get("log"))
+		if name == "-" && len(options) == 0 {
+			continue
+		}
+		fv := v.Field(i)
+		if sf.Anonymous && name == "" {
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct && fv.Type() != timeType && depth < maxStructDepth {
+				o = structFields(fv, depth+1, o)
+				continue
+			}
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+		if options["omitempty"] && fv.IsZero() {
+			continue
+		}
+		if options["redact"] {
+			o = append(o, field{name, Redacted{}})
+			continue
+		}
+		o = append(o, field{name, reflectValue(fv, depth+1)})
+	}
+	return o
+}
+
+func parseTag(tag string) (string, map[string]bool) {
+	parts := strings.Split(tag, ",")
+	options := make(map[string]bool, len(parts)-1)
+	for _, o := range parts[1:] {
+		options[o] = true
+	}
+	return parts[0], options
+}
+
+// field is a log.Field, which can’t be used here, as package log depends on
+// this package.
+type field struct {
+	label string
+	value Value
+}
+
+func (f field) Write(w Writer) error {
+	return w.Field(f.label, f.value.Write)
+}
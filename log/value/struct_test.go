@@ -0,0 +1,107 @@
+package value_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+type user struct {
+	Name     string
+	Password string `log:",redact"`
+	Email    string `log:"email,omitempty"`
+	Age      int    `log:"-"`
+	Tags     []string
+	Avatar   []byte `log:",omitempty"`
+	Address  *address
+	Token    token
+	embedded
+	secret string
+}
+
+type address struct {
+	Street string `log:"street"`
+}
+
+type embedded struct {
+	Since time.Duration
+}
+
+type token string
+
+func (token) LogValue() value.Value {
+	return value.String("tok…")
+}
+
+type cyclic struct {
+	Next *cyclic
+}
+
+type selfValuer struct {
+	N int
+}
+
+func (s selfValuer) LogValue() value.Value {
+	return value.Struct{Value: s}
+}
+
+func TestStructWrite(t *testing.T) {
+	c := &cyclic{}
+	c.Next = c
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{nil, "<nil>"},
+		{(*user)(nil), "<nil>"},
+		{1, "1"},
+		{token("secret"), "tok…"},
+		{
+			user{Name: "a", Password: "p", Age: 1, Tags: []string{"b", "c"}, Token: "t", embedded: embedded{time.Second}, secret: "s"},
+			"{Name: a; Password: [REDACTED]; Tags: [b, c]; Address: <nil>; Token: tok…; Since: 1s}",
+		},
+		{
+			&user{Email: "e", Avatar: []byte("abc"), Address: &address{"s"}},
+			"{Name: ; Password: [REDACTED]; email: e; Tags: []; Avatar: YWJj; Address: {street: s}; Token: tok…; Since: 0s}",
+		},
+		{struct{ Err error }{errors.New("failed")}, "{Err: failed}"},
+		{struct {
+			At    time.Time
+			Ratio float32
+			OK    bool
+			N     uint
+		}{time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC), 0.5, true, 2}, "{At: 2022-03-09T19:51:00Z; Ratio: 0.5; OK: true; N: 2}"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Struct{Value: %#v}.Write(…)", tt.v)
+		var w value.BytesWriter
+		if err := (value.Struct{Value: tt.v}).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+
+	t.Run("stops at cycles", func(t *testing.T) {
+		var p interface{}
+		p = &p
+		for _, v := range []interface{}{c, p, selfValuer{1}} {
+			var w value.BytesWriter
+			if err := (value.Struct{Value: v}).Write(&w); err != nil {
+				t.Errorf("value.Struct{Value: %T}.Write(…) = %v, want nil", v, err)
+			}
+		}
+	})
+}
+
+func TestRedactedWrite(t *testing.T) {
+	var w value.BytesWriter
+	if err := (value.Redacted{}).Write(&w); err != nil {
+		t.Errorf("value.Redacted{}.Write(…) = %v, want nil", err)
+	} else if got, want := string(w.Bytes), value.RedactedPlaceholder; got != want {
+		t.Errorf("value.Redacted{}.Write(…) = %#v, want %#v", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+package value
+
+import "time"
+
+// Time log.Value that’ll write Value.
+type Time struct {
+	Value time.Time
+}
+
+// Write w.Time(t.Value), if w is a TimeWriter, w.Reflect(t.Value), otherwise.
+func (t Time) Write(w Writer) error {
+	if tw, ok := w.(TimeWriter); ok {
+		return tw.Time(t.Value)
+	}
+	return w.Reflect(t.Value)
+}
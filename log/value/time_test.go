@@ -0,0 +1,27 @@
+package value_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/now/x/log/value"
+)
+
+func TestTimeWrite(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{time.Date(2022, time.March, 9, 19, 51, 0, 1, time.UTC), "2022-03-09T19:51:00.000000001Z"},
+	}
+	for _, tt := range tests {
+		expression := fmt.Sprintf("value.Time{Value: %#v}.Write(…)", tt.t)
+		var w value.BytesWriter
+		if err := (value.Time{Value: tt.t}).Write(&w); err != nil {
+			t.Errorf("%s = %v, want %#v", expression, err, nil)
+		} else if got := string(w.Bytes); got != tt.want {
+			t.Errorf("%s = %#v, want %#v", expression, got, tt.want)
+		}
+	}
+}
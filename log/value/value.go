@@ -0,0 +1,13 @@
+package value
+
+// Value that can write itself to a Writer.
+//
+// This is the same interface as log.Value, which is an alias for it, and it’s
+// defined here so that values can contain other values.
+type Value interface {
+	// Write receiver to the given Writer.
+	//
+	// The receiver may use any of the methods provided by the Writer interface
+	// to provide a marshaling of itself.
+	Write(Writer) error
+}
@@ -3,6 +3,7 @@ package value
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // Writer of typed data.
@@ -23,6 +24,66 @@ type Writer interface {
 	Field(string, func(Writer) error) error
 }
 
+// BoolWriter is a Writer that can write bools.
+//
+// Bool.Write uses this if available, falling back on Writer.Reflect otherwise.
+// This, and the following Writer extensions, allow Writers that were written
+// before the corresponding value type was introduced to keep working.
+type BoolWriter interface {
+	Bool(bool) error
+}
+
+// Float64Writer is a Writer that can write float64s.
+//
+// Float64.Write uses this if available, falling back on Writer.Reflect
+// otherwise.
+type Float64Writer interface {
+	Float64(float64) error
+}
+
+// DurationWriter is a Writer that can write time.Durations.
+//
+// Duration.Write uses this if available, falling back on Writer.Reflect
+// otherwise.
+type DurationWriter interface {
+	Duration(time.Duration) error
+}
+
+// TimeWriter is a Writer that can write time.Times.
+//
+// Time.Write uses this if available, falling back on Writer.Reflect otherwise.
+type TimeWriter interface {
+	Time(time.Time) error
+}
+
+// BinaryWriter is a Writer that can write binary data.
+//
+// Bytes.Write uses this if available, falling back on Writer.Reflect
+// otherwise.
+type BinaryWriter interface {
+	Binary([]byte) error
+}
+
+// ObjectWriter is a Writer that can write nested objects.
+//
+// Object.Write uses this if available, writing its elements directly to the
+// Writer otherwise.
+type ObjectWriter interface {
+	// Object opens a nested scope, calls a function that’ll write the members
+	// of the object, usually using Field, and then closes the scope.
+	Object(func(Writer) error) error
+}
+
+// ArrayWriter is a Writer that can write nested arrays.
+//
+// Array.Write uses this if available, writing its elements directly to the
+// Writer otherwise.
+type ArrayWriter interface {
+	// Array opens a nested scope, calls a function that’ll write the elements
+	// of the array, and then closes the scope.
+	Array(func(Writer) error) error
+}
+
 func panicNilCheck(err interface{}, a interface{}, w Writer) error {
 	if v := reflect.ValueOf(a); a == nil || v.Kind() == reflect.Ptr && v.IsNil() {
 		return w.String("<nil>")
@@ -0,0 +1,54 @@
+package value_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/now/x/log/value"
+)
+
+// basicWriter only implements Writer, not any of its extensions, recording
+// what’s written by Int and Reflect.
+type basicWriter struct {
+	written []interface{}
+}
+
+func (w *basicWriter) Int(i int) error {
+	w.written = append(w.written, i)
+	return nil
+}
+
+func (w *basicWriter) Int64(int64) error   { return nil }
+func (w *basicWriter) String(string) error { return nil }
+
+func (w *basicWriter) Reflect(r interface{}) error {
+	w.written = append(w.written, r)
+	return nil
+}
+
+func (w *basicWriter) Field(_ string, f func(value.Writer) error) error {
+	return f(w)
+}
+
+func TestWriterExtensionFallbacks(t *testing.T) {
+	at := time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)
+	var w basicWriter
+	for _, v := range []value.Value{
+		value.Bool(true),
+		value.Bytes("abc"),
+		value.Duration(time.Second),
+		value.Float64(0.5),
+		value.Time{Value: at},
+		value.Object{field{"a", value.Int(1)}},
+		value.Array{value.Int(2), value.Int(3)},
+	} {
+		if err := v.Write(&w); err != nil {
+			t.Errorf("%#v.Write(…) = %v, want nil", v, err)
+		}
+	}
+	if diff := cmp.Diff(w.written, []interface{}{true, []byte("abc"), time.Second, 0.5, at, 1, 2, 3}); diff != "" {
+		t.Errorf("Write(…) to Writer without extensions diff -got +want\n%s", diff)
+	}
+}
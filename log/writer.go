@@ -0,0 +1,18 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// syncWriter serializes writes to an io.Writer shared by a tree of Loggers.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(b)
+}
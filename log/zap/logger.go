@@ -1,7 +1,11 @@
 package zap
 
 import (
+	"strings"
+	"time"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/now/x/log"
 	"github.com/now/x/log/value"
@@ -11,55 +15,147 @@ import (
 //
 // A log.Field{Label: l, Value: v} is mapped to a zap.Field z as follows:
 //
-// If v = value.Error{Err: err}, z = zap.Error(l, err).
+// If v = value.Array{…}, z = zap.Array(l, m), where m is a
+// zapcore.ArrayMarshaler that writes the elements of v to the
+// zapcore.ArrayEncoder.
+//
+// If v = value.Bool(b), z = zap.Bool(l, b).
+//
+// If v = value.Bytes(b), z = zap.Binary(l, b).
+//
+// If v = value.Caller{…}, there’s no z, as v is instead used as the caller of
+// the entry, provided that the field is given to Entry.
+//
+// If v = value.Duration(d), z = zap.Duration(l, d).
+//
+// If v = value.Error{Err: err}, z = zap.NamedError(l, err).
+//
+// If v = value.Float64(f), z = zap.Float64(l, f).
+//
+// If v = value.Func(f), z = zap.Inline(m), where m is a
+// zapcore.ObjectMarshaler that calls f with a value.Writer that writes to the
+// zapcore.ObjectEncoder under l.  As zap only marshals fields of entries that
+// are enabled, f is only called for such entries.  As zap marshals the fields
+// given to With right away, a log.Field of v given to With, and any fields
+// given to With after it, are instead kept by the Logger, in order, and added
+// to each entry, before the fields given to Entry.
 //
 // If v = value.Int64(i), z = zap.Int64(l, i).
 //
+// If v = value.Object{…}, z = zap.Object(l, m), where m is a
+// zapcore.ObjectMarshaler that writes the elements of v to the
+// zapcore.ObjectEncoder.
+//
 // If v = value.Reflect{Value: r}, z = zap.Reflect(l, r).
 //
+// If v = value.Stack{…}, there’s no z, as v is instead used as the stack of the
+// entry, formatted like zap’s own, provided that the field is given to Entry.
+//
 // If v = value.String(s), z = zap.String(l, s).
 //
 // If v = value.Stringer{Value: s}, z = zap.Stringer(l, s).
 //
-// Otherwise, which shouldn’t happen, z = zap.Any(l, v).
+// If v = value.Time{Value: t}, z = zap.Time(l, t).
+//
+// Otherwise, z = zap.Inline(m), where m is a zapcore.ObjectMarshaler that
+// writes the log.Field to the zapcore.ObjectEncoder.  This is, for example, how
+// a value.Struct is written, so that any redacted fields stay redacted.
 type Logger struct {
 	Zap *zap.Logger
+
+	fields []log.Field // fields are those given to With from the first value.Func on.
 }
 
-// Entry delegates to l.Zap.Info(message, fields...).
+// Entry delegates to l.Zap.Info(message, fields...), with the caller and stack
+// of the entry set to any value.Caller and value.Stack fields.
 func (l Logger) Entry(message string, fields ...log.Field) error {
-	l.Zap.Info(message, zapFields(fields...)...)
+	ce := l.Zap.Check(zap.InfoLevel, message)
+	if ce == nil {
+		return nil
+	}
+	rest := make([]log.Field, 0, len(l.fields)+len(fields))
+	rest = append(rest, l.fields...)
+	for _, f := range fields {
+		switch v := f.Value.(type) {
+		case value.Caller:
+			ce.Entry.Caller = zapcore.EntryCaller{Defined: true, File: v.File, Line: v.Line, Function: v.Function}
+		case value.Stack:
+			ce.Entry.Stack = stack(v)
+		default:
+			rest = append(rest, f)
+		}
+	}
+	ce.Write(zapFields(rest...)...)
 	return nil
 }
 
 // Named is a new Logger wrapping l.Zap.Named(name).
 func (l Logger) Named(name string) log.Logger {
-	return Logger{l.Zap.Named(name)}
+	return Logger{l.Zap.Named(name), l.fields}
 }
 
-// With is a new Logger wrapping l.Zap.With(fields...).
+// With is a new Logger wrapping l.Zap.With(fields...), except for any
+// value.Func field and the fields following it, which are kept for Entry.
 func (l Logger) With(fields ...log.Field) log.Logger {
-	return Logger{l.Zap.With(zapFields(fields...)...)}
+	if len(l.fields) > 0 {
+		return Logger{l.Zap, append(l.fields[:len(l.fields):len(l.fields)], fields...)}
+	}
+	for i, f := range fields {
+		if _, ok := f.Value.(value.Func); ok {
+			return Logger{l.Zap.With(zapFields(fields[:i]...)...), append([]log.Field(nil), fields[i:]...)}
+		}
+	}
+	return Logger{l.Zap.With(zapFields(fields...)...), nil}
+}
+
+// stack is s formatted like zap formats stacks.
+func stack(s value.Stack) string {
+	var b strings.Builder
+	for i, f := range s {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.String())
+	}
+	return b.String()
 }
 
 func zapFields(fields ...log.Field) []zap.Field {
 	zapFields := make([]zap.Field, len(fields))
 	for i, f := range fields {
 		switch v := f.Value.(type) {
+		case value.Array:
+			zapFields[i] = zap.Array(f.Label, arrayMarshaler(writeValues(v)))
+		case value.Bool:
+			zapFields[i] = zap.Bool(f.Label, bool(v))
+		case value.Bytes:
+			zapFields[i] = zap.Binary(f.Label, []byte(v))
+		case value.Duration:
+			zapFields[i] = zap.Duration(f.Label, time.Duration(v))
 		case value.Error:
-			zapFields[i] = zap.Error(v.Err)
+			zapFields[i] = zap.NamedError(f.Label, v.Err)
+		case value.Float64:
+			zapFields[i] = zap.Float64(f.Label, float64(v))
+		case value.Func:
+			zapFields[i] = zap.Inline(objectMarshaler(f.Write))
 		case value.Int:
 			zapFields[i] = zap.Int(f.Label, int(v))
 		case value.Int64:
 			zapFields[i] = zap.Int64(f.Label, int64(v))
+		case value.Object:
+			zapFields[i] = zap.Object(f.Label, objectMarshaler(writeValues(v)))
 		case value.Reflect:
 			zapFields[i] = zap.Reflect(f.Label, v.Value)
 		case value.String:
 			zapFields[i] = zap.String(f.Label, string(v))
 		case value.Stringer:
 			zapFields[i] = zap.Stringer(f.Label, v.Value)
+		case value.Time:
+			zapFields[i] = zap.Time(f.Label, v.Value)
 		default:
-			zapFields[i] = zap.Any(f.Label, v)
+			zapFields[i] = zap.Inline(objectMarshaler(f.Write))
 		}
 	}
 	return zapFields
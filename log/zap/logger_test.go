@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/now/x/log"
+	"github.com/now/x/log/value"
 	xzap "github.com/now/x/log/zap"
 )
 
@@ -31,13 +33,26 @@ func TestLoggerEntry(t *testing.T) {
 			field log.Field
 			want  string
 		}{
+			{log.Bool("ok", true), `"ok": true`},
+			{log.Bytes("data", []byte("abc")), `"data": "YWJj"`},
+			{log.Duration("elapsed", 1500*time.Millisecond), `"elapsed": 1500000000`},
 			{log.Error(fmt.Errorf("failed")), `"error": "failed"`},
+			{log.NamedError("cause", fmt.Errorf("failed")), `"cause": "failed"`},
+			{log.Float64("ratio", 0.5), `"ratio": 0.5`},
 			{log.Int("ID", 1), `"ID": 1`},
 			{log.Int64("ID", 1), `"ID": 1`},
 			{log.Int64("neg", -1), `"neg": -1`},
 			{log.Reflect("values", map[string]int{"a": 1}), `"values": {"a":1}`},
 			{log.String("name", "something"), `"name": "something"`},
 			{log.Stringer("string", stringer("stringed")), `"string": "stringed"`},
+			{log.Object("object", log.Int("a", 1), log.Object("b", log.String("c", "d")), log.Array("e", value.Bool(true))), `"object": {"a": 1, "b": {"c": "d"}, "e": [true]}`},
+			{log.Array("array", value.Int(1), value.Object{log.Int("a", 2)}, value.Array{value.String("b")}, value.Bytes("abc")), `"array": [1, {"a": 2}, ["b"], "YWJj"]`},
+			{log.Array("fields", log.Int("a", 1)), `"fields": [{"a": 1}]`},
+			{log.Struct("user", struct {
+				Name     string `log:"name"`
+				Password string `log:",redact"`
+			}{"a", "b"}), `"user": {"name": "a", "Password": "[REDACTED]"}`},
+			{log.Time("at", time.Date(2022, time.March, 9, 19, 51, 0, 0, time.UTC)), `"at": 1646855460000000000`},
 		}
 		for _, tt := range tests {
 			var b buffer
@@ -49,6 +64,77 @@ func TestLoggerEntry(t *testing.T) {
 	})
 }
 
+func TestLoggerLazy(t *testing.T) {
+	calls := 0
+	lazy := log.Lazy("a", func() log.Value {
+		calls++
+		return value.Int(1)
+	})
+
+	var b buffer
+	l := xzap.Logger{Zap: logger(&b).Zap.WithOptions(zap.IncreaseLevel(zap.WarnLevel))}
+	l.Entry("bc", lazy)
+	if calls != 0 {
+		t.Errorf("zap.Logger{…}.Entry(\"bc\", log.Lazy(…)) with disabled level called f %d times, want 0", calls)
+	}
+
+	logger(&b).Entry("bc", lazy)
+	if got, want := string(b.Bytes()), "info\tbc\t{\"a\": 1}"; got != want {
+		t.Errorf("zap.Logger{…}.Entry(\"bc\", log.Lazy(…)) = %#v, want %#v", got, want)
+	} else if calls != 1 {
+		t.Errorf("zap.Logger{…}.Entry(\"bc\", log.Lazy(…)) called f %d times, want 1", calls)
+	}
+
+	t.Run("With", func(t *testing.T) {
+		calls = 0
+		var b buffer
+		l := xzap.Logger{Zap: logger(&b).Zap.WithOptions(zap.IncreaseLevel(zap.WarnLevel))}
+		l.With(lazy).Named("d").Entry("bc")
+		if calls != 0 {
+			t.Errorf("zap.Logger{…}.With(log.Lazy(…)).Entry(\"bc\") with disabled level called f %d times, want 0", calls)
+		}
+
+		logger(&b).With(log.Int("d", 0), lazy, log.Int("e", 2)).With(log.Int("g", 4)).Entry("bc", log.Int("f", 3))
+		if got, want := string(b.Bytes()), "info\tbc\t{\"d\": 0, \"a\": 1, \"e\": 2, \"g\": 4, \"f\": 3}"; got != want {
+			t.Errorf("zap.Logger{…}.With(log.Lazy(…), …).Entry(\"bc\", …) = %#v, want %#v", got, want)
+		} else if calls != 1 {
+			t.Errorf("zap.Logger{…}.With(log.Lazy(…), …).Entry(\"bc\", …) called f %d times, want 1", calls)
+		}
+	})
+}
+
+func TestLoggerCallerAndStack(t *testing.T) {
+	var b buffer
+	l := xzap.Logger{
+		Zap: zap.New(
+			zapcore.NewCore(
+				zapcore.NewJSONEncoder(
+					zapcore.EncoderConfig{
+						MessageKey:     "M",
+						CallerKey:      "C",
+						FunctionKey:    "F",
+						StacktraceKey:  "S",
+						SkipLineEnding: true,
+						EncodeCaller:   zapcore.FullCallerEncoder,
+					},
+				),
+				&b,
+				zap.LevelEnablerFunc(func(zapcore.Level) bool {
+					return true
+				}),
+			),
+		),
+	}
+	l.Entry("bc",
+		log.Field{Label: "caller", Value: value.Caller{Function: "f", File: "a.go", Line: 1}},
+		log.Field{Label: "stacktrace", Value: value.Stack{{Function: "f", File: "a.go", Line: 1}, {Function: "g", File: "b.go", Line: 2}}},
+		log.Int("a", 1))
+	want := `{"C":"a.go:1","F":"f","M":"bc","a":1,"S":"f\n\ta.go:1\ng\n\tb.go:2"}`
+	if got := string(b.Bytes()); got != want {
+		t.Errorf("zap.Logger{…}.Entry(\"bc\", caller, stack, …) = %#v, want %#v", got, want)
+	}
+}
+
 func TestLoggerNamed(t *testing.T) {
 	var b buffer
 	logger(&b).Named("a").Entry("bc")
@@ -0,0 +1,161 @@
+package zap
+
+import (
+	"encoding/base64"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/now/x/log/value"
+)
+
+// objectWriter writes values to a zapcore.ObjectEncoder under key.
+type objectWriter struct {
+	enc zapcore.ObjectEncoder
+	key string
+}
+
+func (w objectWriter) Bool(b bool) error {
+	w.enc.AddBool(w.key, b)
+	return nil
+}
+
+func (w objectWriter) Binary(b []byte) error {
+	w.enc.AddBinary(w.key, b)
+	return nil
+}
+
+func (w objectWriter) Duration(d time.Duration) error {
+	w.enc.AddDuration(w.key, d)
+	return nil
+}
+
+func (w objectWriter) Float64(f float64) error {
+	w.enc.AddFloat64(w.key, f)
+	return nil
+}
+
+func (w objectWriter) Int(i int) error {
+	w.enc.AddInt(w.key, i)
+	return nil
+}
+
+func (w objectWriter) Int64(i int64) error {
+	w.enc.AddInt64(w.key, i)
+	return nil
+}
+
+func (w objectWriter) Reflect(r interface{}) error {
+	return w.enc.AddReflected(w.key, r)
+}
+
+func (w objectWriter) String(s string) error {
+	w.enc.AddString(w.key, s)
+	return nil
+}
+
+func (w objectWriter) Time(t time.Time) error {
+	w.enc.AddTime(w.key, t)
+	return nil
+}
+
+func (w objectWriter) Object(f func(value.Writer) error) error {
+	return w.enc.AddObject(w.key, objectMarshaler(f))
+}
+
+func (w objectWriter) Array(f func(value.Writer) error) error {
+	return w.enc.AddArray(w.key, arrayMarshaler(f))
+}
+
+func (w objectWriter) Field(label string, f func(value.Writer) error) error {
+	return f(objectWriter{w.enc, label})
+}
+
+// arrayWriter appends values to a zapcore.ArrayEncoder.
+type arrayWriter struct {
+	enc zapcore.ArrayEncoder
+}
+
+func (w arrayWriter) Bool(b bool) error {
+	w.enc.AppendBool(b)
+	return nil
+}
+
+func (w arrayWriter) Binary(b []byte) error {
+	w.enc.AppendString(base64.StdEncoding.EncodeToString(b))
+	return nil
+}
+
+func (w arrayWriter) Duration(d time.Duration) error {
+	w.enc.AppendDuration(d)
+	return nil
+}
+
+func (w arrayWriter) Float64(f float64) error {
+	w.enc.AppendFloat64(f)
+	return nil
+}
+
+func (w arrayWriter) Int(i int) error {
+	w.enc.AppendInt(i)
+	return nil
+}
+
+func (w arrayWriter) Int64(i int64) error {
+	w.enc.AppendInt64(i)
+	return nil
+}
+
+func (w arrayWriter) Reflect(r interface{}) error {
+	return w.enc.AppendReflected(r)
+}
+
+func (w arrayWriter) String(s string) error {
+	w.enc.AppendString(s)
+	return nil
+}
+
+func (w arrayWriter) Time(t time.Time) error {
+	w.enc.AppendTime(t)
+	return nil
+}
+
+func (w arrayWriter) Object(f func(value.Writer) error) error {
+	return w.enc.AppendObject(objectMarshaler(f))
+}
+
+func (w arrayWriter) Array(f func(value.Writer) error) error {
+	return w.enc.AppendArray(arrayMarshaler(f))
+}
+
+// Field appends an object consisting of a single member.
+func (w arrayWriter) Field(label string, f func(value.Writer) error) error {
+	return w.enc.AppendObject(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		return f(objectWriter{enc, label})
+	}))
+}
+
+func objectMarshaler(f func(value.Writer) error) zapcore.ObjectMarshaler {
+	return zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		return f(objectWriter{enc: enc})
+	})
+}
+
+func arrayMarshaler(f func(value.Writer) error) zapcore.ArrayMarshaler {
+	return zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		return f(arrayWriter{enc})
+	})
+}
+
+// writeValues is a function that writes values, which is how the elements of a
+// value.Object or value.Array are written within the scope opened for them.
+func writeValues(values []value.Value) func(value.Writer) error {
+	return func(w value.Writer) error {
+		for _, v := range values {
+			if err := v.Write(w); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -9,3 +9,8 @@ type ClockFunc func() time.Time
 func (f ClockFunc) Now() time.Time {
 	return f()
 }
+
+// StoppedAt is a Clock whose Now is always t.
+func StoppedAt(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
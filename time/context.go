@@ -14,7 +14,7 @@ func Default(ctx context.Context) context.Context {
 //
 // This is useful for testing, where time is often necessary to control.
 func Stopped(ctx context.Context, t time.Time) context.Context {
-	return Using(ctx, ClockFunc(func() time.Time { return t }))
+	return Using(ctx, StoppedAt(t))
 }
 
 // Using is ctxʹ ≈ ctx such that In(ctxʹ) is c.Now().